@@ -23,6 +23,8 @@ type ConfigurationApiRouter interface {
 	GetConfigurations(http.ResponseWriter, *http.Request)
 	PostConfiguration(http.ResponseWriter, *http.Request)
 	PutConfigurationById(http.ResponseWriter, *http.Request)
+	TestConfiguration(http.ResponseWriter, *http.Request)
+	TestConfigurationById(http.ResponseWriter, *http.Request)
 }
 
 // CustomizationApiRouter defines the required methods for binding the api requests to a responses for the CustomizationApi
@@ -36,6 +38,7 @@ type CustomizationApiRouter interface {
 // The DevicesApiRouter implementation should parse necessary information from the http request,
 // pass the data to a DevicesApiServicer to perform the required actions, then write the service results to the http response.
 type DevicesApiRouter interface {
+	GetAccessPoints(http.ResponseWriter, *http.Request)
 	GetDevices(http.ResponseWriter, *http.Request)
 }
 
@@ -57,6 +60,8 @@ type ConfigurationApiServicer interface {
 	GetConfigurations(context.Context) (ImplResponse, error)
 	PostConfiguration(context.Context, Configuration) (ImplResponse, error)
 	PutConfigurationById(context.Context, int64, Configuration) (ImplResponse, error)
+	TestConfiguration(context.Context, Configuration) (ImplResponse, error)
+	TestConfigurationById(context.Context, int64) (ImplResponse, error)
 }
 
 // CustomizationApiServicer defines the api actions for the CustomizationApi service
@@ -72,6 +77,7 @@ type CustomizationApiServicer interface {
 // while the service implementation can be ignored with the .openapi-generator-ignore file
 // and updated with the logic required for the API.
 type DevicesApiServicer interface {
+	GetAccessPoints(context.Context, int64) (ImplResponse, error)
 	GetDevices(context.Context, int64) (ImplResponse, error)
 }
 
@@ -80,6 +80,18 @@ func (c *ConfigurationApiController) Routes() Routes {
 			"/v1/configs/{config-id}",
 			c.PutConfigurationById,
 		},
+		{
+			"TestConfiguration",
+			strings.ToUpper("Post"),
+			"/v1/configs/test",
+			c.TestConfiguration,
+		},
+		{
+			"TestConfigurationById",
+			strings.ToUpper("Post"),
+			"/v1/configs/{config-id}/test",
+			c.TestConfigurationById,
+		},
 	}
 }
 
@@ -190,3 +202,47 @@ func (c *ConfigurationApiController) PutConfigurationById(w http.ResponseWriter,
 	EncodeJSONResponse(result.Body, &result.Code, w)
 
 }
+
+// TestConfiguration - Tests the connection of an unsaved endpoint
+func (c *ConfigurationApiController) TestConfiguration(w http.ResponseWriter, r *http.Request) {
+	configurationParam := Configuration{}
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&configurationParam); err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	if err := AssertConfigurationRequired(configurationParam); err != nil {
+		c.errorHandler(w, r, err, nil)
+		return
+	}
+	result, err := c.service.TestConfiguration(r.Context(), configurationParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
+// TestConfigurationById - Tests the connection of an endpoint
+func (c *ConfigurationApiController) TestConfigurationById(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	configIdParam, err := parseInt64Parameter(params["config-id"], true)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+
+	result, err := c.service.TestConfigurationById(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
@@ -47,6 +47,12 @@ func NewDevicesApiController(s DevicesApiServicer, opts ...DevicesApiOption) Rou
 // Routes returns all the api routes for the DevicesApiController
 func (c *DevicesApiController) Routes() Routes {
 	return Routes{
+		{
+			"GetAccessPoints",
+			strings.ToUpper("Get"),
+			"/v1/access-points",
+			c.GetAccessPoints,
+		},
 		{
 			"GetDevices",
 			strings.ToUpper("Get"),
@@ -56,6 +62,25 @@ func (c *DevicesApiController) Routes() Routes {
 	}
 }
 
+// GetAccessPoints - List all access points mapped to eliona assets
+func (c *DevicesApiController) GetAccessPoints(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	configIdParam, err := parseInt64Parameter(query.Get("configId"), false)
+	if err != nil {
+		c.errorHandler(w, r, &ParsingError{Err: err}, nil)
+		return
+	}
+	result, err := c.service.GetAccessPoints(r.Context(), configIdParam)
+	// If an error occurred, encode the error with the status code
+	if err != nil {
+		c.errorHandler(w, r, err, &result)
+		return
+	}
+	// If no error, encode the body and the result code
+	EncodeJSONResponse(result.Body, &result.Code, w)
+
+}
+
 // GetDevices - List all devices mapped to eliona assets
 func (c *DevicesApiController) GetDevices(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query()
@@ -13,6 +13,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -42,6 +43,25 @@ func (e *RequiredError) Error() string {
 	return fmt.Sprintf("required field '%s' is zero value.", e.Field)
 }
 
+// FieldError describes an invalid field of a request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError indicates that fields of a request body are invalid. It lists every invalid field.
+type ValidationError struct {
+	Fields []FieldError `json:"fields"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field.Field, field.Message))
+	}
+	return fmt.Sprintf("invalid fields: %s", strings.Join(messages, "; "))
+}
+
 // ErrorHandler defines the required method for handling error. You may implement it and inject this into a controller if
 // you would like errors to be handled differently from the DefaultErrorHandler
 type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error, result *ImplResponse)
@@ -55,6 +75,9 @@ func DefaultErrorHandler(w http.ResponseWriter, r *http.Request, err error, resu
 	} else if _, ok := err.(*RequiredError); ok {
 		// Handle missing required errors
 		EncodeJSONResponse(err.Error(), func(i int) *int { return &i }(http.StatusUnprocessableEntity), w)
+	} else if validationErr, ok := err.(*ValidationError); ok {
+		// Handle invalid fields
+		EncodeJSONResponse(validationErr, func(i int) *int { return &i }(http.StatusBadRequest), w)
 	} else {
 		// Handle all other errors
 		EncodeJSONResponse(err.Error(), &result.Code, w)
@@ -0,0 +1,52 @@
+/*
+ * Glutz App API
+ *
+ * API to access and configure the Glutz
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// AccessPoint - The schema `AccessPoint` maps each pair of Eliona project id and Glutz access point (i.e. door) to an Eliona asset. The devices of the access point are placed under this asset. The mapping is created automatically by the app and should be read only.
+type AccessPoint struct {
+
+	// References the configured endpoint (see `Configuration`)
+	ConfigId int32 `json:"configId,omitempty"`
+
+	// The project id for which the Eliona asset is created (see `project_ids` in `Configuration`)
+	ProjectId string `json:"projectId,omitempty"`
+
+	// References the asset id in Eliona which is automatically created by the app
+	AssetId int32 `json:"assetId,omitempty"`
+
+	// References the access point id on the Glutz server
+	AccessPointId string `json:"accessPointId,omitempty"`
+
+	// State of the mapping. `user_deleted` if the asset was deleted in Eliona and is not recreated (see `missingAssetPolicy` in `Configuration`)
+	State string `json:"state,omitempty"`
+
+	// The asset name last written by the app
+	AssetName string `json:"assetName,omitempty"`
+
+	// The asset id of the room or building the asset is placed under in the location tree of Eliona
+	ParentAssetId int32 `json:"parentAssetId,omitempty"`
+}
+
+// AssertAccessPointRequired checks if the required fields are not zero-ed
+func AssertAccessPointRequired(obj AccessPoint) error {
+	return nil
+}
+
+// AssertRecurseAccessPointRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of AccessPoint (e.g. [][]AccessPoint), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseAccessPointRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aAccessPoint, ok := obj.(AccessPoint)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertAccessPointRequired(aAccessPoint)
+	})
+}
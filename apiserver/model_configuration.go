@@ -9,7 +9,7 @@
 
 package apiserver
 
-// Configuration - Each configuration defines access to a Glutz endpoint.
+// Configuration - Each configuration defines access to a Glutz endpoint. Fields omitted on update keep their stored values.
 type Configuration struct {
 
 	// Internal identifier for the configured endpoint (created automatically). This identifier always has to be used if you remove or update existing configured endpoints.
@@ -18,7 +18,7 @@ type Configuration struct {
 	// Username for login
 	Username string `json:"username,omitempty"`
 
-	// Password for login
+	// Password for login. It is stored encrypted and never returned. Omit it on update to keep the stored password.
 	Password string `json:"password,omitempty"`
 
 	// url for glutz endpoint
@@ -44,10 +44,51 @@ type Configuration struct {
 
 	// List of Eliona project ids for which this endpoint should collect data. For each project id all glutz devices are automatically created as an asset in Eliona. The mapping between Eliona is stored as an asset mapping in the glutz app and can be read with the ´DeviceMapping´ endpoint.
 	ProjIds *[]string `json:"projIds,omitempty"`
+
+	// Maximum number of calls sent to the Glutz server in a single JSON-RPC batch request
+	BatchSize int32 `json:"batchSize,omitempty"`
+
+	CycleSummary *CycleSummary `json:"cycleSummary,omitempty"`
+
+	Health *ConnectionHealth `json:"health,omitempty"`
+
+	// Handling of assets whose device is no longer reported by the Glutz server: `keep` the asset, `mark` it as orphaned or `delete` the asset and its mapping
+	OrphanPolicy string `json:"orphanPolicy,omitempty"`
+
+	// Handling of mappings whose asset was deleted in Eliona: `recreate` the asset or `ignore` the device by setting the mapping state to `user_deleted`
+	MissingAssetPolicy string `json:"missingAssetPolicy,omitempty"`
+
+	// Flag to update name and location of the assets if the location of a device changes in Glutz. Disable it to keep names and locations edited in Eliona.
+	SyncAssetNames *bool `json:"syncAssetNames,omitempty"`
+
+	// Battery level in percent below which the low battery alarm of a device is triggered
+	BatteryLowThreshold *int32 `json:"batteryLowThreshold,omitempty"`
+
+	// Number of new communication errors of a device within one refresh interval above which the communication alarm is triggered
+	CommunicationErrorsThreshold *int32 `json:"communicationErrorsThreshold,omitempty"`
+
+	// Time in seconds since the last update of a device after which the device is shown as offline and the offline alarm is triggered
+	StaleThreshold int32 `json:"staleThreshold,omitempty"`
+
+	// Maximum number of door open commands sent to the Glutz server at the same time
+	MaxConcurrentCommands int32 `json:"maxConcurrentCommands,omitempty"`
+
+	// IANA time zone of the Glutz server (e.g. `Europe/Zurich`), used for the last update of devices reported without zone. Defaults to the time zone of the app.
+	TimeZone *string `json:"timeZone,omitempty"`
 }
 
 // AssertConfigurationRequired checks if the required fields are not zero-ed
 func AssertConfigurationRequired(obj Configuration) error {
+	if obj.CycleSummary != nil {
+		if err := AssertCycleSummaryRequired(*obj.CycleSummary); err != nil {
+			return err
+		}
+	}
+	if obj.Health != nil {
+		if err := AssertConnectionHealthRequired(*obj.Health); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
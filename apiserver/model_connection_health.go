@@ -0,0 +1,50 @@
+/*
+ * Glutz App API
+ *
+ * API to access and configure the Glutz
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+import (
+	"time"
+)
+
+// ConnectionHealth - State of the connection to the Glutz server of a configuration, updated by each refresh cycle.
+type ConnectionHealth struct {
+
+	// State of the connection in the last refresh cycle: `connected`, `disconnected` or `unauthorized`. `invalid_password` if the stored password cannot be decrypted with the current key.
+	State string `json:"state,omitempty"`
+
+	// Time of the last refresh cycle which read the devices from the Glutz server
+	LastSync *time.Time `json:"lastSync,omitempty"`
+
+	// Message of the last error of the last refresh cycle, empty if the cycle had no errors
+	LastError string `json:"lastError,omitempty"`
+
+	// Number of refresh cycles in a row which could not read the devices from the Glutz server
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Duration of the last refresh cycle in milliseconds
+	LastCycleDuration int64 `json:"lastCycleDuration,omitempty"`
+}
+
+// AssertConnectionHealthRequired checks if the required fields are not zero-ed
+func AssertConnectionHealthRequired(obj ConnectionHealth) error {
+	return nil
+}
+
+// AssertRecurseConnectionHealthRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ConnectionHealth (e.g. [][]ConnectionHealth), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseConnectionHealthRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aConnectionHealth, ok := obj.(ConnectionHealth)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertConnectionHealthRequired(aConnectionHealth)
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * Glutz App API
+ *
+ * API to access and configure the Glutz
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// ConnectionTestResult - Result of a test call reading the devices from the Glutz server of a configuration
+type ConnectionTestResult struct {
+
+	// Flag whether the Glutz server answered the request
+	Reachable bool `json:"reachable"`
+
+	// Flag whether the Glutz server accepted the credentials
+	Authorized bool `json:"authorized"`
+
+	// Duration of the request in milliseconds
+	Latency int64 `json:"latency"`
+
+	// Number of devices reported by the Glutz server
+	DeviceCount int32 `json:"deviceCount"`
+
+	// Error message if the devices could not be read
+	Error string `json:"error,omitempty"`
+}
+
+// AssertConnectionTestResultRequired checks if the required fields are not zero-ed
+func AssertConnectionTestResultRequired(obj ConnectionTestResult) error {
+	return nil
+}
+
+// AssertRecurseConnectionTestResultRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of ConnectionTestResult (e.g. [][]ConnectionTestResult), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseConnectionTestResultRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aConnectionTestResult, ok := obj.(ConnectionTestResult)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertConnectionTestResultRequired(aConnectionTestResult)
+	})
+}
@@ -0,0 +1,46 @@
+/*
+ * Glutz App API
+ *
+ * API to access and configure the Glutz
+ *
+ * API version: 1.0.0
+ * Generated by: OpenAPI Generator (https://openapi-generator.tech)
+ */
+
+package apiserver
+
+// CycleSummary - Outcome of the last refresh cycle of a configuration. Counts refer to device mappings, i.e. pairs of Glutz device and Eliona project.
+type CycleSummary struct {
+
+	// Number of devices whose data was written to Eliona
+	Ok int32 `json:"ok,omitempty"`
+
+	// Number of devices which could not be read or written
+	Failed int32 `json:"failed,omitempty"`
+
+	// Number of assets created in Eliona
+	Created int32 `json:"created,omitempty"`
+
+	// Number of devices skipped because the mapped asset no longer exists in Eliona
+	Skipped int32 `json:"skipped,omitempty"`
+
+	// Number of mappings whose device is no longer reported by the Glutz server
+	Orphaned int32 `json:"orphaned,omitempty"`
+}
+
+// AssertCycleSummaryRequired checks if the required fields are not zero-ed
+func AssertCycleSummaryRequired(obj CycleSummary) error {
+	return nil
+}
+
+// AssertRecurseCycleSummaryRequired recursively checks if required fields are not zero-ed in a nested slice.
+// Accepts only nested slice of CycleSummary (e.g. [][]CycleSummary), otherwise ErrTypeAssertionError is thrown.
+func AssertRecurseCycleSummaryRequired(objSlice interface{}) error {
+	return AssertRecurseInterfaceRequired(objSlice, func(obj interface{}) error {
+		aCycleSummary, ok := obj.(CycleSummary)
+		if !ok {
+			return ErrTypeAssertionError
+		}
+		return AssertCycleSummaryRequired(aCycleSummary)
+	})
+}
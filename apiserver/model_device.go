@@ -26,6 +26,18 @@ type Device struct {
 
 	// References the location
 	LocationId string `json:"location_id,omitempty"`
+
+	// State of the mapping. `user_deleted` if the asset was deleted in Eliona and is not recreated (see `missingAssetPolicy` in `Configuration`)
+	State string `json:"state,omitempty"`
+
+	// The asset name last written by the app
+	AssetName string `json:"assetName,omitempty"`
+
+	// The asset id of the access point the asset is placed under in the location tree of Eliona
+	ParentAssetId int32 `json:"parentAssetId,omitempty"`
+
+	// The communication error counter of the device at the last refresh, used to detect new communication errors
+	CommunicationErrors *int64 `json:"communicationErrors,omitempty"`
 }
 
 // AssertDeviceRequired checks if the required fields are not zero-ed
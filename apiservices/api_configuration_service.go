@@ -11,20 +11,27 @@ package apiservices
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"glutz/apiserver"
 	"glutz/conf"
+	"glutz/eliona"
+	"glutz/glutz"
 	"net/http"
+	"strings"
+	"time"
 )
 
 // ConfigurationApiService is a service that implements the logic for the ConfigurationApiServicer
 // This service should implement the business logic for every endpoint for the ConfigurationApi API.
 // Include any external packages or services that will be required by this service.
 type ConfigurationApiService struct {
+	eliona eliona.Api
 }
 
-// NewConfigurationApiService creates a default api service
-func NewConfigurationApiService() apiserver.ConfigurationApiServicer {
-	return &ConfigurationApiService{}
+// NewConfigurationApiService creates a default api service. Eliona is used to check the projects of a configuration.
+func NewConfigurationApiService(el eliona.Api) apiserver.ConfigurationApiServicer {
+	return &ConfigurationApiService{eliona: el}
 }
 
 // DeleteConfigurationById - Deletes an endpoint
@@ -48,7 +55,7 @@ func (s *ConfigurationApiService) GetConfigurationById(ctx context.Context, conf
 	if config == nil {
 		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
 	}
-	return apiserver.Response(http.StatusOK, config), nil
+	return apiserver.Response(http.StatusOK, redactPassword(*config)), nil
 }
 
 // GetConfigurations - Get all endpoint configurations
@@ -57,23 +64,109 @@ func (s *ConfigurationApiService) GetConfigurations(ctx context.Context) (apiser
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
+	for i := range configs {
+		configs[i] = redactPassword(configs[i])
+	}
 	return apiserver.Response(http.StatusOK, configs), nil
 }
 
 // PostConfiguration - Creates an example configuration
 func (s *ConfigurationApiService) PostConfiguration(ctx context.Context, configuration apiserver.Configuration) (apiserver.ImplResponse, error) {
+	if err := s.validateConfiguration(ctx, &configuration); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
 	insertedConfig, err := conf.InsertConfig(ctx, configuration)
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.Response(http.StatusCreated, insertedConfig), nil
+	return apiserver.Response(http.StatusCreated, redactPassword(insertedConfig)), nil
 }
 
 // PutConfigurationById - Updates an endpoint
 func (s *ConfigurationApiService) PutConfigurationById(ctx context.Context, configId int64, configuration apiserver.Configuration) (apiserver.ImplResponse, error) {
+	if err := s.validateConfiguration(ctx, &configuration); err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
 	upsertedConfig, err := conf.UpsertConfigById(ctx, configId, configuration)
 	if err != nil {
 		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
 	}
-	return apiserver.Response(http.StatusCreated, upsertedConfig), nil
+	return apiserver.Response(http.StatusCreated, redactPassword(upsertedConfig)), nil
+}
+
+// Passwords are never returned by the API
+func redactPassword(config apiserver.Configuration) apiserver.Configuration {
+	config.Password = ""
+	return config
+}
+
+// Removes trailing slashes from the URL and checks all fields of the configuration including the existence
+// of its projects in Eliona. Invalid fields are returned as apiserver.ValidationError.
+func (s *ConfigurationApiService) validateConfiguration(ctx context.Context, configuration *apiserver.Configuration) error {
+	configuration.Url = strings.TrimRight(configuration.Url, "/")
+	invalid := conf.ValidateConfig(*configuration)
+	if configuration.ProjIds != nil {
+		for _, projectId := range *configuration.ProjIds {
+			if projectId == "" {
+				continue
+			}
+			exists, err := s.eliona.ExistProject(ctx, projectId)
+			if err != nil {
+				return fmt.Errorf("checking project %s: %w", projectId, err)
+			}
+			if !exists {
+				invalid = append(invalid, apiserver.FieldError{Field: "projIds", Message: fmt.Sprintf("project %s does not exist", projectId)})
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return &apiserver.ValidationError{Fields: invalid}
+	}
+	return nil
+}
+
+// TestConfiguration - Tests the connection of an unsaved endpoint
+func (s *ConfigurationApiService) TestConfiguration(ctx context.Context, configuration apiserver.Configuration) (apiserver.ImplResponse, error) {
+	return apiserver.Response(http.StatusOK, testConnection(ctx, configuration)), nil
+}
+
+// TestConfigurationById - Tests the connection of an endpoint
+func (s *ConfigurationApiService) TestConfigurationById(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	config, err := conf.GetConfig(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	if config == nil {
+		return apiserver.ImplResponse{Code: http.StatusNotFound}, err
+	}
+	if err := conf.PasswordError(*config); err != nil {
+		return apiserver.Response(http.StatusOK, apiserver.ConnectionTestResult{Error: err.Error()}), nil
+	}
+	return apiserver.Response(http.StatusOK, testConnection(ctx, *config)), nil
+}
+
+// Reads the devices from the Glutz server of the configuration without writing anything to Eliona
+func testConnection(ctx context.Context, config apiserver.Configuration) apiserver.ConnectionTestResult {
+	started := time.Now()
+	devices, err := glutz.NewClient(config, conf.RequestTimeout(config), conf.BatchSize(config)).GetDevices(ctx)
+	result := apiserver.ConnectionTestResult{
+		Latency: time.Since(started).Milliseconds(),
+	}
+	if err == nil {
+		result.Reachable = true
+		result.Authorized = true
+		result.DeviceCount = int32(len(devices))
+		return result
+	}
+	result.Error = err.Error()
+	var rpcError *glutz.Error
+	switch {
+	case errors.Is(err, glutz.ErrUnauthorized), errors.Is(err, glutz.ErrUnexpectedStatus):
+		result.Reachable = true
+	case errors.As(err, &rpcError):
+		result.Reachable = true
+		result.Authorized = true
+		result.Error = rpcError.Message
+	}
+	return result
 }
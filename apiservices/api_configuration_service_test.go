@@ -0,0 +1,131 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package apiservices
+
+import (
+	"context"
+	"errors"
+	"glutz/apiserver"
+	"glutz/eliona"
+	"glutz/glutz/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+func TestTestConfiguration(t *testing.T) {
+	server := mock.NewServer("user", "secret", mock.DemoFixtures(3))
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+	closed := httptest.NewServer(server)
+	closed.Close()
+
+	tests := []struct {
+		name        string
+		url         string
+		password    string
+		rpcError    string
+		reachable   bool
+		authorized  bool
+		deviceCount int32
+		err         string
+	}{
+		{name: "ok", url: httpServer.URL, password: "secret", reachable: true, authorized: true, deviceCount: 3},
+		{name: "wrong password", url: httpServer.URL, password: "wrong", reachable: true, err: "calling eAccess.getModel: glutz: unauthorized"},
+		{name: "server error", url: httpServer.URL, password: "secret", rpcError: "internal error", reachable: true, authorized: true, err: "internal error"},
+		{name: "unreachable", url: closed.URL, password: "secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server.ClearErrors()
+			if tt.rpcError != "" {
+				server.InjectError("eAccess.getModel", "", 1, tt.rpcError)
+			}
+			config := apiserver.Configuration{Username: "user", Password: tt.password, Url: tt.url, RequestTimeout: 5}
+			response, err := NewConfigurationApiService(eliona.NewFake()).TestConfiguration(context.Background(), config)
+			if err != nil || response.Code != http.StatusOK {
+				t.Fatalf("got %d, %v", response.Code, err)
+			}
+			result := response.Body.(apiserver.ConnectionTestResult)
+			if result.Reachable != tt.reachable || result.Authorized != tt.authorized || result.DeviceCount != tt.deviceCount {
+				t.Errorf("got %+v", result)
+			}
+			if tt.err != "" && result.Error != tt.err {
+				t.Errorf("got error %q, want %q", result.Error, tt.err)
+			}
+			if tt.err == "" && tt.reachable && result.Error != "" {
+				t.Errorf("got unexpected error %q", result.Error)
+			}
+			if !tt.reachable && result.Error == "" {
+				t.Error("expected error for unreachable server")
+			}
+		})
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	el := eliona.NewFake()
+	el.AddProject("1")
+	service := &ConfigurationApiService{eliona: el}
+
+	valid := apiserver.Configuration{
+		Url:                          "https://glutz.example.com/",
+		Username:                     "user",
+		RequestTimeout:               120,
+		RefreshInterval:              60,
+		BatteryLowThreshold:          common.Ptr[int32](100),
+		CommunicationErrorsThreshold: common.Ptr[int32](0),
+		TimeZone:                     common.Ptr("Europe/Zurich"),
+		ProjIds:                      &[]string{"1"},
+	}
+	if err := service.validateConfiguration(context.Background(), &valid); err != nil {
+		t.Fatalf("got error %v for valid configuration", err)
+	}
+	if valid.Url != "https://glutz.example.com" {
+		t.Errorf("got url %s, want trailing slash removed", valid.Url)
+	}
+
+	invalid := apiserver.Configuration{
+		Url:                 "glutz.example.com",
+		RefreshInterval:     -1,
+		BatteryLowThreshold: common.Ptr[int32](0),
+		OrphanPolicy:        "forget",
+		TimeZone:            common.Ptr("Mars/Olympus"),
+		ProjIds:             &[]string{"1", "2", ""},
+	}
+	err := service.validateConfiguration(context.Background(), &invalid)
+	var validationErr *apiserver.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("got error %v, want validation error", err)
+	}
+	var fields []string
+	for _, field := range validationErr.Fields {
+		fields = append(fields, field.Field)
+	}
+	want := []string{"url", "username", "requestTimeout", "refreshInterval", "batteryLowThreshold", "timeZone", "orphanPolicy", "projIds", "projIds"}
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("got invalid fields %v, want %v", fields, want)
+	}
+
+	recorder := httptest.NewRecorder()
+	apiserver.DefaultErrorHandler(recorder, httptest.NewRequest(http.MethodPost, "/v1/configs", nil), err, &apiserver.ImplResponse{Code: http.StatusInternalServerError})
+	if recorder.Code != http.StatusBadRequest || !strings.Contains(recorder.Body.String(), `"field":"refreshInterval"`) {
+		t.Errorf("got response %d %s, want 400 listing the invalid fields", recorder.Code, recorder.Body.String())
+	}
+}
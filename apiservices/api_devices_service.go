@@ -27,6 +27,15 @@ func NewDevicesApiService() apiserver.DevicesApiServicer {
 	return &DevicesApiService{}
 }
 
+// GetAccessPoints - List all access points mapped to eliona assets
+func (s *DevicesApiService) GetAccessPoints(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
+	accessPoints, err := conf.GetAccessPoints(ctx, configId)
+	if err != nil {
+		return apiserver.ImplResponse{Code: http.StatusInternalServerError}, err
+	}
+	return apiserver.Response(http.StatusOK, accessPoints), nil
+}
+
 // GetDevices - List all devices mapped to eliona assets
 func (s *DevicesApiService) GetDevices(ctx context.Context, configId int64) (apiserver.ImplResponse, error) {
 	devices, err := conf.GetDevices(ctx, configId)
@@ -17,6 +17,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-eliona/dashboard"
@@ -28,7 +29,10 @@ import (
 	"glutz/eliona"
 	"glutz/glutz"
 	nethttp "net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
@@ -39,24 +43,23 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-type Request struct {
-	Jsonrpc string        `json:"jsonrpc"`
-	ID      string        `json:"id"`
-	Method  string        `json:"method"`
-	Params  []interface{} `json:"params"`
-}
-
-type DeviceParams struct {
-	DeviceID string `json:"deviceid"`
+type OutputData struct {
+	Open float64
 }
 
-type Duration struct {
-	Duration string `json:"Duration"`
+// Values written to the open attribute in Eliona and the command actions they request
+var outputActions = map[float64]string{
+	1: conf.CommandActionOpen,
+	2: conf.CommandActionClose,
 }
 
-type OutputData struct {
-	Open float64
-}
+// Values of the openable attribute in Eliona. Permanently open doors are reported by the Glutz server.
+const (
+	openableClosed          int32 = 0
+	openableOpen            int32 = 1
+	openableFailed          int32 = 2
+	openablePermanentlyOpen int32 = 3
+)
 
 func initialization() {
 	ctx := context.Background()
@@ -68,13 +71,44 @@ func initialization() {
 	// Init the app before the first run.
 	app.Init(conn, app.AppName(),
 		asset.InitAssetTypeFile("eliona/asset-type-glutz_device.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_access_point.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_building.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_room.json"),
 		dashboard.InitWidgetTypeFile("eliona/widget-type-glutz.json"),
 		app.ExecSqlFile("conf/init.sql"),
 	)
+
+	// Patch the app to v1.1.0
+	app.Patch(conn, app.AppName(), "010100",
+		app.ExecSqlFile("conf/v1.1.0.sql"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_device.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_access_point.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_building.json"),
+		asset.InitAssetTypeFile("eliona/asset-type-glutz_room.json"),
+	)
+
+	// Encrypt passwords stored in plaintext, e.g. before the key was set
+	encryptPasswords(ctx)
+}
+
+func encryptPasswords(ctx context.Context) {
+	count, err := conf.EncryptPasswords(ctx)
+	if errors.Is(err, conf.ErrNoPasswordKey) {
+		log.Warn("conf", "%s is not set, passwords are stored in plaintext", conf.PasswordKeyEnv)
+		return
+	}
+	if err != nil {
+		log.Fatal("conf", "Error encrypting passwords: %v", err)
+		return
+	}
+	if count > 0 {
+		log.Info("conf", "Encrypted %d passwords stored in plaintext", count)
+	}
 }
 
 func checkConfigAndSetActiveState() {
-	configs, err := conf.GetConfigs(context.Background())
+	ctx := context.Background()
+	configs, err := conf.GetConfigs(ctx)
 	if err != nil {
 		log.Fatal("conf", "Couldn't read configs from DB: %v", err)
 		return
@@ -89,6 +123,15 @@ func checkConfigAndSetActiveState() {
 			continue
 		}
 
+		// Skip config if its password cannot be decrypted and set inactive. The other configs keep running.
+		if err := conf.PasswordError(config); err != nil {
+			if conf.IsConfigActive(config) {
+				conf.SetConfigActiveState(config.ConfigId, false)
+				log.Error("conf", "Stopped collecting with Configuration %d: %v", config.ConfigId, err)
+			}
+			continue
+		}
+
 		// Signals that this config is active
 		if !conf.IsConfigActive(config) {
 			conf.SetConfigActiveState(config.ConfigId, true)
@@ -101,7 +144,7 @@ func checkConfigAndSetActiveState() {
 		common.RunOnceWithParam(func(config apiserver.Configuration) {
 			log.Info("main", "Processing devices for configId %d started", config.ConfigId)
 
-			processDevices(config)
+			processDevices(ctx, confStore{}, eliona.NewClient(conf.RequestTimeout(config)), config)
 
 			log.Info("main", "Processing devices for configId %d finished", config.ConfigId)
 
@@ -110,109 +153,662 @@ func checkConfigAndSetActiveState() {
 	}
 }
 
-func processDevices(config apiserver.Configuration) {
-	Devices, devicelist, err := fetchDevicesAndCreateGlutzProperty(config)
+// syncCycle collects the outcome of one refresh cycle of a configuration. Counts refer to device mappings,
+// i.e. pairs of Glutz device and Eliona project.
+type syncCycle struct {
+	summary apiserver.CycleSummary
+	errors  []error
+}
+
+func (c *syncCycle) fail(err error) {
+	c.summary.Failed++
+	c.errors = append(c.errors, err)
+}
+
+// Logs all failures of the cycle and stores the summary for the configuration
+func (c *syncCycle) report(ctx context.Context, st store, configId int64) {
+	for _, err := range c.errors {
+		log.Error("devices", "Config %d: %v", configId, err)
+	}
+	log.Info("devices", "Config %d: %d devices ok, %d failed, %d created, %d skipped, %d orphaned",
+		configId, c.summary.Ok, c.summary.Failed, c.summary.Created, c.summary.Skipped, c.summary.Orphaned)
+	if err := st.SetConfigCycleSummary(ctx, configId, c.summary); err != nil {
+		log.Error("conf", "Error storing cycle summary for config %d: %v", configId, err)
+	}
+}
+
+// Returns the connection health after a cycle which read the devices from the Glutz server. The last
+// error is cleared if the cycle had no failures.
+func (c *syncCycle) health(now time.Time, duration time.Duration) apiserver.ConnectionHealth {
+	health := apiserver.ConnectionHealth{
+		State:             conf.ConnectionStateConnected,
+		LastSync:          &now,
+		LastCycleDuration: duration.Milliseconds(),
+	}
+	if n := len(c.errors); n > 0 {
+		health.LastError = c.errors[n-1].Error()
+	}
+	return health
+}
+
+// Returns the connection health after a cycle which could not read the devices from the Glutz server
+func failedHealth(previous *apiserver.ConnectionHealth, err error, duration time.Duration) apiserver.ConnectionHealth {
+	health := apiserver.ConnectionHealth{
+		State:               conf.ConnectionStateDisconnected,
+		LastError:           err.Error(),
+		ConsecutiveFailures: 1,
+		LastCycleDuration:   duration.Milliseconds(),
+	}
+	if errors.Is(err, glutz.ErrUnauthorized) {
+		health.State = conf.ConnectionStateUnauthorized
+	}
+	if previous != nil {
+		health.LastSync = previous.LastSync
+		health.ConsecutiveFailures += previous.ConsecutiveFailures
+	}
+	return health
+}
+
+func setHealth(ctx context.Context, st store, configId int64, health apiserver.ConnectionHealth) {
+	if err := st.SetConfigHealth(ctx, configId, health); err != nil {
+		log.Error("conf", "Error storing connection health for config %d: %v", configId, err)
+	}
+}
+
+func processDevices(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration) {
+	started := time.Now()
+	client := newGlutzClient(config)
+	devices, devicelist, deviceErrors, err := fetchDevicesAndCreateGlutzProperty(ctx, st, client, config)
 	if err != nil {
+		setHealth(ctx, st, config.ConfigId, failedHealth(config.Health, err, time.Since(started)))
+		return
+	}
+	var cycle syncCycle
+	if err := migrateDeviceAssets(ctx, st, el, config); err != nil {
+		// Writing data before the migration would create a second asset for each device
+		cycle.errors = append(cycle.errors, fmt.Errorf("migrating device assets: %w", err))
+		cycle.report(ctx, st, config.ConfigId)
+		setHealth(ctx, st, config.ConfigId, cycle.health(time.Now(), time.Since(started)))
 		return
 	}
+	var locations []*locationAssets
 	if config.ProjIds != nil {
 		for _, projId := range *config.ProjIds {
-			for device := range devicelist.Result {
-				confDevice, err := getOrCreateMapping(config, projId, devicelist, device, Devices)
+			accessPoints := newAccessPointAssets(st, el, config, projId)
+			locations = append(locations, accessPoints.locations)
+			for _, result := range devicelist {
+				deviceId := result.Deviceid
+				if err := deviceErrors[deviceId]; err != nil {
+					cycle.fail(fmt.Errorf("device %s in project %s: %w", deviceId, projId, err))
+					continue
+				}
+				parentAssetId, err := accessPoints.parentAssetId(ctx, devices[deviceId])
+				if err != nil {
+					cycle.fail(fmt.Errorf("device %s in project %s: %w", deviceId, projId, err))
+					continue
+				}
+				confDevice, created, err := getOrCreateMapping(ctx, st, el, config, projId, devices[deviceId], parentAssetId)
+				if err != nil {
+					cycle.fail(fmt.Errorf("device %s in project %s: %w", deviceId, projId, err))
+					continue
+				}
+				if created {
+					cycle.summary.Created++
+				}
+				if confDevice == nil {
+					cycle.summary.Skipped++
+					continue
+				}
+				err = sendData(ctx, st, el, config, devices, confDevice)
 				if err != nil {
-					return
+					cycle.fail(fmt.Errorf("device %s in project %s: sending data: %w", deviceId, projId, err))
+					continue
 				}
-				err = sendData(Devices, device, confDevice)
+				err = syncAlarmRules(ctx, st, el, config, confDevice.AssetId)
 				if err != nil {
-					return
+					cycle.fail(fmt.Errorf("device %s in project %s: %w", deviceId, projId, err))
+					continue
 				}
+				cycle.summary.Ok++
 			}
 		}
 	}
+	syncReportedDoorStates(ctx, st, el, config, devices, &cycle)
+	if len(devicelist) > 0 {
+		reconcileOrphans(ctx, st, el, config, devicelist, &cycle)
+		reconcileOrphanedAccessPoints(ctx, st, el, config, devicelist, &cycle)
+	} else {
+		// A controller reports no devices e.g. while it restarts. Deleting all assets would lose their history.
+		log.Warn("devices", "Config %d: no devices reported, skipping orphaned devices", config.ConfigId)
+	}
+	for _, location := range locations {
+		if err := location.removeUnused(ctx); err != nil {
+			cycle.errors = append(cycle.errors, fmt.Errorf("project %s: %w", location.projId, err))
+		}
+	}
+	cycle.report(ctx, st, config.ConfigId)
+	setHealth(ctx, st, config.ConfigId, cycle.health(time.Now(), time.Since(started)))
 }
 
-func fetchDevicesAndCreateGlutzProperty(config apiserver.Configuration) ([]glutz.DeviceDb, *glutz.DeviceGlutz, error) {
-	var Devices []glutz.DeviceDb
-	deviceList, err := GetDevices(config)
+// Handles mappings of devices no longer reported by the Glutz server according to the orphan policy of the config
+func reconcileOrphans(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, devicelist []glutz.DeviceResult, cycle *syncCycle) {
+	reported := make(map[string]bool)
+	for _, result := range devicelist {
+		reported[result.Deviceid] = true
+	}
+	mappings, err := st.GetDevices(ctx, config.ConfigId)
 	if err != nil {
-		return nil, nil, err
+		cycle.errors = append(cycle.errors, fmt.Errorf("reading mappings: %w", err))
+		return
+	}
+	policy := conf.OrphanPolicy(config)
+	for _, mapping := range mappings {
+		if reported[mapping.DeviceId] {
+			continue
+		}
+		cycle.summary.Orphaned++
+		log.Debug("devices", "Device %s of asset %d is no longer reported, policy %s", mapping.DeviceId, mapping.AssetId, policy)
+		switch policy {
+		case conf.OrphanPolicyMark:
+			err = eliona.UpsertOrphanedData(ctx, el, mapping.AssetId)
+		case conf.OrphanPolicyDelete:
+			err = deleteAssetAndMapping(ctx, st, el, mapping)
+		default:
+			continue
+		}
+		if err != nil {
+			cycle.fail(fmt.Errorf("orphaned device %s in project %s: %w", mapping.DeviceId, mapping.ProjectId, err))
+		}
 	}
-	openableDurationSet, err := setAccessPointPropertyOpenableDuration(config)
+}
+
+// Handles mappings of access points without any device reported by the Glutz server. They are not counted in
+// the summary of the cycle, which counts device mappings.
+func reconcileOrphanedAccessPoints(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, devicelist []glutz.DeviceResult, cycle *syncCycle) {
+	reported := make(map[string]bool)
+	for _, result := range devicelist {
+		reported[result.AccessPointId] = true
+	}
+	mappings, err := st.GetAccessPoints(ctx, config.ConfigId)
 	if err != nil {
-		return nil, nil, err
+		cycle.errors = append(cycle.errors, fmt.Errorf("reading access point mappings: %w", err))
+		return
+	}
+	policy := conf.OrphanPolicy(config)
+	for _, mapping := range mappings {
+		if reported[mapping.AccessPointId] {
+			continue
+		}
+		switch policy {
+		case conf.OrphanPolicyMark:
+			err = eliona.UpsertOrphanedData(ctx, el, mapping.AssetId)
+		case conf.OrphanPolicyDelete:
+			err = deleteAccessPointAssetAndMapping(ctx, st, el, mapping)
+		default:
+			continue
+		}
+		if err != nil {
+			cycle.errors = append(cycle.errors, fmt.Errorf("orphaned access point %s in project %s: %w", mapping.AccessPointId, mapping.ProjectId, err))
+		}
+	}
+}
+
+func deleteAccessPointAssetAndMapping(ctx context.Context, st store, el eliona.Api, mapping apiserver.AccessPoint) error {
+	if err := el.DeleteAsset(ctx, mapping.AssetId); err != nil {
+		return fmt.Errorf("deleting asset %d: %w", mapping.AssetId, err)
+	}
+	if err := st.DeleteAccessPoint(ctx, int64(mapping.ConfigId), mapping.ProjectId, mapping.AccessPointId); err != nil {
+		return fmt.Errorf("deleting mapping: %w", err)
+	}
+	log.Info("devices", "Deleted asset %d of access point %s no longer reported", mapping.AssetId, mapping.AccessPointId)
+	return nil
+}
+
+func deleteAssetAndMapping(ctx context.Context, st store, el eliona.Api, mapping apiserver.Device) error {
+	if err := el.DeleteAsset(ctx, mapping.AssetId); err != nil {
+		return fmt.Errorf("deleting asset %d: %w", mapping.AssetId, err)
+	}
+	if err := st.DeleteDevice(ctx, int64(mapping.ConfigId), mapping.ProjectId, mapping.DeviceId); err != nil {
+		return fmt.Errorf("deleting mapping: %w", err)
+	}
+	if err := st.DeleteAlarmRules(ctx, mapping.AssetId); err != nil {
+		return fmt.Errorf("deleting alarm rules: %w", err)
+	}
+	log.Info("devices", "Deleted asset %d of device %s no longer reported", mapping.AssetId, mapping.DeviceId)
+	return nil
+}
+
+// Reads all devices from the Glutz server. Reading the status or location of a single device may fail without
+// affecting the other devices, the error is returned for the device id.
+func fetchDevicesAndCreateGlutzProperty(ctx context.Context, st store, client *glutz.Client, config apiserver.Configuration) (glutz.DevicesDb, []glutz.DeviceResult, map[string]error, error) {
+	deviceList, err := client.GetDevices(ctx)
+	if err != nil {
+		log.Error("devices", "Error reading devices: %v", err)
+		return nil, nil, nil, err
+	}
+	// Initialize the access point property "openable duration" on the Glutz server
+	openableDurationSet, err := client.SetAccessPointProperty(ctx, glutz.OpenableDurationProperty, "", "0")
+	if err != nil {
+		log.Error("devices", "Error setting access point property: %v", err)
 	}
 	if openableDurationSet {
-		conf.SetConfigInitialisedState(config.ConfigId, true)
+		if err := st.SetConfigInitialisedState(ctx, config.ConfigId, true); err != nil {
+			log.Error("conf", "Error setting config %d initialized: %v", config.ConfigId, err)
+		}
+	}
+	// Request status and location of all devices in batches instead of one request per call
+	batch := client.NewBatch()
+	statuses := make(map[string]*glutz.Result[glutz.DeviceStatus])
+	locations := make(map[string]*glutz.Result[glutz.Location])
+	for _, result := range deviceList {
+		if _, ok := statuses[result.Deviceid]; !ok {
+			statuses[result.Deviceid] = batch.GetDeviceStatus(result.Deviceid)
+		}
+		if _, ok := locations[result.AccessPointId]; !ok {
+			locations[result.AccessPointId] = batch.GetLocation(result.AccessPointId)
+		}
+	}
+	batch.Send(ctx)
+	devices, deviceErrors := collectDevices(deviceList, statuses, locations)
+	return devices, deviceList, deviceErrors, nil
+}
+
+// Combines the status (by device id) and location (by access point id) of each device into the device data
+// keyed by device id. Devices without status or location are returned as errors instead.
+func collectDevices(deviceList []glutz.DeviceResult, statuses map[string]*glutz.Result[glutz.DeviceStatus], locations map[string]*glutz.Result[glutz.Location]) (glutz.DevicesDb, map[string]error) {
+	devices := make(glutz.DevicesDb)
+	deviceErrors := make(map[string]error)
+	for _, result := range deviceList {
+		status, ok := statuses[result.Deviceid]
+		if !ok {
+			deviceErrors[result.Deviceid] = fmt.Errorf("no status requested")
+			continue
+		}
+		if status.Err != nil {
+			deviceErrors[result.Deviceid] = fmt.Errorf("reading device status: %w", status.Err)
+			continue
+		}
+		location, ok := locations[result.AccessPointId]
+		if !ok {
+			deviceErrors[result.Deviceid] = fmt.Errorf("no location requested for access point %s", result.AccessPointId)
+			continue
+		}
+		if location.Err != nil {
+			deviceErrors[result.Deviceid] = fmt.Errorf("reading device access point: %w", location.Err)
+			continue
+		}
+		devices[result.Deviceid] = glutz.DeviceDb{
+			DeviceId:      result.Deviceid,
+			AccessPointId: result.AccessPointId,
+			BatteryLevel:  status.Value.BatteryLevel,
+			Openings:      status.Value.Openings,
+			Building:      location.Value.Building,
+			Room:          location.Value.Room,
+			AccessPoint:   location.Value.AccessPoint,
+			OperatingMode: status.Value.OperatingMode,
+			Firmware:      status.Value.Firmware,
+
+			BatteryAlarm:        status.Value.BatteryAlarm,
+			BatteryPowered:      status.Value.BatteryPowered,
+			CommunicationErrors: status.Value.CommunicationErrors,
+			IrWakeups:           status.Value.IrWakeups,
+			RfWakeups:           status.Value.RfWakeups,
+			LastError:           status.Value.LastError,
+			LastUpdate:          status.Value.LastUpdate,
+		}
 	}
-	for result := range deviceList.Result {
-		deviceid := deviceList.Result[result].Deviceid
-		deviceStatus, err := GetDeviceStatus(config, deviceid)
+	return devices, deviceErrors
+}
+
+// locationAssets creates the building and room assets of a project in Eliona. Each asset is upserted once
+// per cycle, afterwards the cached id is used. Glutz reports locations by name only, so the global asset
+// identifier of a location asset is built from its escaped names. A renamed building or room is a new asset,
+// the asset of the old name is removed with removeUnused when nothing is placed under it anymore.
+type locationAssets struct {
+	el       eliona.Api
+	configId int64
+	projId   string
+	ids      map[string]int32
+}
+
+func newLocationAssets(el eliona.Api, configId int64, projId string) *locationAssets {
+	return &locationAssets{el: el, configId: configId, projId: projId, ids: make(map[string]int32)}
+}
+
+// Returns the id of the asset the device is placed under: its room, or its building if the room is unknown.
+// Returns 0 if the device has no location.
+func (l *locationAssets) parentAssetId(ctx context.Context, device glutz.DeviceDb) (int32, error) {
+	var buildingId int32
+	if device.Building != "" {
+		var err error
+		globalAssetId := fmt.Sprintf("glutz:%d:building:%s", l.configId, url.QueryEscape(device.Building))
+		buildingId, err = l.upsert(ctx, eliona.BuildingAssetType, globalAssetId, device.Building, 0)
 		if err != nil {
-			return nil, nil, err
+			return 0, fmt.Errorf("upserting building: %w", err)
+		}
+	}
+	if device.Room == "" {
+		return buildingId, nil
+	}
+	globalAssetId := fmt.Sprintf("glutz:%d:room:%s:%s", l.configId, url.QueryEscape(device.Building), url.QueryEscape(device.Room))
+	roomId, err := l.upsert(ctx, eliona.RoomAssetType, globalAssetId, device.Room, buildingId)
+	if err != nil {
+		return 0, fmt.Errorf("upserting room: %w", err)
+	}
+	return roomId, nil
+}
+
+func (l *locationAssets) upsert(ctx context.Context, assetType string, globalAssetId string, name string, parentAssetId int32) (int32, error) {
+	if assetId, ok := l.ids[globalAssetId]; ok {
+		return assetId, nil
+	}
+	assetId, err := eliona.UpsertLocationAsset(ctx, l.el, l.projId, assetType, globalAssetId, name, parentAssetId)
+	if err != nil {
+		return 0, err
+	}
+	l.ids[globalAssetId] = assetId
+	return assetId, nil
+}
+
+// Deletes the building and room assets of the config in the project which were not used in this cycle and
+// have no assets placed under them anymore, e.g. after a room was renamed in Glutz. This includes the location
+// assets of earlier versions of the app, whose global asset identifiers had no building or room prefix.
+func (l *locationAssets) removeUnused(ctx context.Context) error {
+	assets, err := l.el.GetAssets(ctx, l.projId)
+	if err != nil {
+		return fmt.Errorf("reading assets: %w", err)
+	}
+	used := make(map[int32]bool)
+	for _, assetId := range l.ids {
+		used[assetId] = true
+	}
+	children := make(map[int32]int)
+	for _, asset := range assets {
+		if parent := asset.ParentLocationalAssetId.Get(); parent != nil {
+			children[*parent]++
+		}
+	}
+	prefix := fmt.Sprintf("glutz:%d:", l.configId)
+	// Rooms first, so that a building whose rooms are all removed is removed as well
+	for _, assetType := range []string{eliona.RoomAssetType, eliona.BuildingAssetType} {
+		for _, asset := range assets {
+			if asset.AssetType != assetType || !strings.HasPrefix(asset.GlobalAssetIdentifier, prefix) || asset.Id.Get() == nil {
+				continue
+			}
+			assetId := *asset.Id.Get()
+			if used[assetId] || children[assetId] > 0 {
+				continue
+			}
+			if err := l.el.DeleteAsset(ctx, assetId); err != nil {
+				return fmt.Errorf("deleting location asset %d: %w", assetId, err)
+			}
+			if parent := asset.ParentLocationalAssetId.Get(); parent != nil {
+				children[*parent]--
+			}
+			log.Info("devices", "Deleted location asset %d '%s' without assets placed under it", assetId, asset.GetName())
 		}
-		accesspointid := deviceList.Result[result].AccessPointId
-		accessPointId, err := GetLocation(config, accesspointid)
+	}
+	return nil
+}
+
+// accessPointAssets maps the access points of a project to Eliona assets placed under their room. Each access
+// point is synchronized once per cycle, afterwards the cached result is used.
+type accessPointAssets struct {
+	st        store
+	el        eliona.Api
+	locations *locationAssets
+	config    apiserver.Configuration
+	projId    string
+	results   map[string]accessPointResult
+}
+
+type accessPointResult struct {
+	assetId int32
+	err     error
+}
+
+func newAccessPointAssets(st store, el eliona.Api, config apiserver.Configuration, projId string) *accessPointAssets {
+	return &accessPointAssets{
+		st:        st,
+		el:        el,
+		locations: newLocationAssets(el, config.ConfigId, projId),
+		config:    config,
+		projId:    projId,
+		results:   make(map[string]accessPointResult),
+	}
+}
+
+// Returns the id of the asset the device is placed under: the asset of its access point, or the room or building
+// if the access point is ignored.
+func (a *accessPointAssets) parentAssetId(ctx context.Context, device glutz.DeviceDb) (int32, error) {
+	if result, ok := a.results[device.AccessPointId]; ok {
+		return result.assetId, result.err
+	}
+	assetId, err := a.sync(ctx, device)
+	if err != nil {
+		err = fmt.Errorf("access point %s: %w", device.AccessPointId, err)
+	}
+	a.results[device.AccessPointId] = accessPointResult{assetId: assetId, err: err}
+	return assetId, err
+}
+
+// Creates or updates the asset and mapping of the access point of the device and writes its info data. The
+// missing asset policy and the name sync flag of the config apply like for devices.
+func (a *accessPointAssets) sync(ctx context.Context, device glutz.DeviceDb) (int32, error) {
+	parentAssetId, err := a.locations.parentAssetId(ctx, device)
+	if err != nil {
+		return 0, err
+	}
+	mapping, err := a.st.GetAccessPoint(ctx, a.config.ConfigId, a.projId, device.AccessPointId)
+	if err != nil {
+		return 0, fmt.Errorf("reading mapping: %w", err)
+	}
+	assetname := device.AccessPoint + ", " + device.Room + ", " + device.Building
+	if mapping == nil {
+		mapping = &apiserver.AccessPoint{ConfigId: int32(a.config.ConfigId), ProjectId: a.projId, AccessPointId: device.AccessPointId}
+		if err := a.upsertAsset(ctx, mapping, assetname, parentAssetId); err != nil {
+			return 0, err
+		}
+		if err := a.st.InsertAccessPoint(ctx, *mapping); err != nil {
+			return 0, fmt.Errorf("inserting mapping: %w", err)
+		}
+		log.Debug("devices", "AssetId %v assigned to access point %v", mapping.AssetId, assetname)
+	} else {
+		policy := conf.MissingAssetPolicy(a.config)
+		if mapping.State == conf.DeviceStateUserDeleted && policy == conf.MissingAssetPolicyIgnore {
+			return parentAssetId, nil
+		}
+		exists, err := a.el.ExistAsset(ctx, mapping.AssetId)
 		if err != nil {
-			return nil, nil, err
+			return 0, fmt.Errorf("checking asset %d: %w", mapping.AssetId, err)
+		}
+		if !exists && policy == conf.MissingAssetPolicyIgnore {
+			mapping.State = conf.DeviceStateUserDeleted
+			if err := a.st.UpdateAccessPoint(ctx, *mapping); err != nil {
+				return 0, fmt.Errorf("setting mapping user deleted: %w", err)
+			}
+			log.Info("devices", "Access point %v is ignored since its asset %v was deleted in eliona", device.AccessPointId, mapping.AssetId)
+			return parentAssetId, nil
 		}
-		Device := glutz.DeviceDb{
-			BatteryLevel:  deviceStatus.Result[0].BatteryLevel,
-			Openings:      deviceStatus.Result[0].Openings,
-			Building:      accessPointId.Result[0],
-			Room:          accessPointId.Result[1],
-			AccessPoint:   accessPointId.Result[2],
-			OperatingMode: deviceStatus.Result[0].OperatingMode,
-			Firmware:      deviceStatus.Result[0].Firmware,
+		changed := conf.IsAssetNameSyncEnabled(a.config) && (mapping.AssetName != assetname || mapping.ParentAssetId != parentAssetId)
+		if !exists || changed {
+			if !exists {
+				log.Info("devices", "Recreating asset of access point %v, replacing deleted asset %v", device.AccessPointId, mapping.AssetId)
+			}
+			if err := a.upsertAsset(ctx, mapping, assetname, parentAssetId); err != nil {
+				return 0, err
+			}
+			if err := a.st.UpdateAccessPoint(ctx, *mapping); err != nil {
+				return 0, fmt.Errorf("updating mapping: %w", err)
+			}
 		}
-		Devices = append(Devices, Device)
 	}
-	return Devices, deviceList, nil
+	return mapping.AssetId, eliona.UpsertAccessPointInfoData(ctx, a.el, device, mapping.AssetId)
+}
+
+func (a *accessPointAssets) upsertAsset(ctx context.Context, mapping *apiserver.AccessPoint, assetname string, parentAssetId int32) error {
+	globalAssetId := fmt.Sprintf("glutz:%d:access-point:%s", a.config.ConfigId, mapping.AccessPointId)
+	assetId, err := eliona.UpsertAccessPointAsset(ctx, a.el, a.projId, globalAssetId, assetname, parentAssetId)
+	if err != nil {
+		return err
+	}
+	mapping.AssetId = assetId
+	mapping.State = conf.DeviceStateActive
+	mapping.AssetName = assetname
+	mapping.ParentAssetId = parentAssetId
+	return nil
 }
 
-func getOrCreateMapping(config apiserver.Configuration, projId string, devicelist *glutz.DeviceGlutz, device int, Devices []glutz.DeviceDb) (*apiserver.Device, error) {
-	confDevice, err := conf.GetDevice(context.Background(), config.ConfigId, projId, devicelist.Result[device].Deviceid)
+// Returns the mapping of the device to an Eliona asset and whether the asset was created. If the mapped asset
+// was deleted in Eliona, it is recreated or the mapping is set to user deleted depending on the config. No
+// mapping is returned for user deleted mappings. The asset is placed under the given parent asset.
+func getOrCreateMapping(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, projId string, device glutz.DeviceDb, parentAssetId int32) (*apiserver.Device, bool, error) {
+	confDevice, err := st.GetDevice(ctx, config.ConfigId, projId, device.DeviceId)
 	if err != nil {
 		log.Error("spaces", "Error when reading devices from configurations")
+		return nil, false, err
 	}
-	assetname := Devices[device].AccessPoint + ", " + Devices[device].Room + ", " + Devices[device].Building
-	locationid := devicelist.Result[device].AccessPointId
+	assetname := device.AccessPoint + ", " + device.Room + ", " + device.Building
 	if confDevice == nil {
-		confDevice, err = createAssetandMapping(config, projId, devicelist.Result[device].Deviceid, assetname, locationid)
-
+		confDevice, err = createAssetandMapping(ctx, st, el, config, projId, device, assetname, parentAssetId)
 		if err != nil {
 			log.Debug("devices", "Error creating asset and mapping")
-			return nil, err
+			return nil, false, err
 		}
-	} else {
-		exists, err := asset.ExistAsset(confDevice.AssetId)
+		return confDevice, true, nil
+	}
+	policy := conf.MissingAssetPolicy(config)
+	if confDevice.State == conf.DeviceStateUserDeleted && policy == conf.MissingAssetPolicyIgnore {
+		return nil, false, nil
+	}
+	exists, err := el.ExistAsset(ctx, confDevice.AssetId)
+	if err != nil {
+		log.Error("devices", "Error when checking if asset already exists")
+		return nil, false, err
+	}
+	if !exists {
+		log.Debug("devices", "Asset with AssetId %v does no longer exist in eliona", confDevice.AssetId)
+		if policy == conf.MissingAssetPolicyRecreate {
+			return recreateAsset(ctx, st, el, assetname, parentAssetId, confDevice)
+		}
+		confDevice.State = conf.DeviceStateUserDeleted
+		err := st.UpdateDevice(ctx, *confDevice)
 		if err != nil {
-			log.Error("devices", "Error when checking if asset already exists")
-			return nil, err
+			return nil, false, fmt.Errorf("setting mapping user deleted: %w", err)
 		}
-		if exists {
-			log.Debug("devices", "Asset already exists for device %v with AssetId %v", assetname, confDevice.AssetId)
-		} else {
-			log.Debug("devices", "Asset with AssetId %v does no longer exist in eliona", confDevice.AssetId)
-			return nil, nil
+		log.Info("devices", "Device %v is ignored since its asset %v was deleted in eliona", device.DeviceId, confDevice.AssetId)
+		return nil, false, nil
+	}
+	log.Debug("devices", "Asset already exists for device %v with AssetId %v", assetname, confDevice.AssetId)
+	if conf.IsAssetNameSyncEnabled(config) {
+		if confDevice.AssetName != assetname || confDevice.ParentAssetId != parentAssetId || confDevice.LocationId != device.AccessPointId {
+			confDevice.LocationId = device.AccessPointId
+			err := updateAsset(ctx, st, el, assetname, parentAssetId, confDevice)
+			if err != nil {
+				return nil, false, err
+			}
 		}
 	}
-	return confDevice, nil
+	return confDevice, false, nil
+}
+
+// Global asset identifier of the asset of a device. Device ids are only unique per Glutz server.
+func deviceGlobalAssetId(configId int64, deviceId string) string {
+	return fmt.Sprintf("glutz:%d:device:%s", configId, deviceId)
+}
+
+// Changes the global asset identifier of the device assets created by earlier versions of the app, which used
+// the device id, to deviceGlobalAssetId. Otherwise the next update of an asset would create a new asset. The
+// migration is recorded in the configuration, so the assets of each configuration are migrated once. After a
+// failure it is repeated in the next cycle.
+func migrateDeviceAssets(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration) error {
+	migrated, err := st.AreDeviceAssetsMigrated(ctx, config.ConfigId)
+	if err != nil {
+		return fmt.Errorf("reading migration state: %w", err)
+	}
+	if migrated {
+		return nil
+	}
+	devices, err := st.GetDevices(ctx, config.ConfigId)
+	if err != nil {
+		return fmt.Errorf("reading mappings: %w", err)
+	}
+	for _, device := range devices {
+		if device.State == conf.DeviceStateUserDeleted {
+			continue
+		}
+		if err := el.SetGlobalAssetId(ctx, device.AssetId, deviceGlobalAssetId(config.ConfigId, device.DeviceId)); err != nil {
+			return fmt.Errorf("migrating asset %d of device %s: %w", device.AssetId, device.DeviceId, err)
+		}
+	}
+	if err := st.SetDeviceAssetsMigrated(ctx, config.ConfigId); err != nil {
+		return fmt.Errorf("storing migration state: %w", err)
+	}
+	log.Info("devices", "Config %d: migrated global asset ids of %d device assets", config.ConfigId, len(devices))
+	return nil
+}
+
+// Updates name and location of the asset after the location of the device changed in Glutz
+func updateAsset(ctx context.Context, st store, el eliona.Api, assetname string, parentAssetId int32, confDevice *apiserver.Device) error {
+	_, err := eliona.UpsertDeviceAsset(ctx, el, confDevice.ProjectId, deviceGlobalAssetId(int64(confDevice.ConfigId), confDevice.DeviceId), assetname, parentAssetId)
+	if err != nil {
+		return fmt.Errorf("updating asset %d: %w", confDevice.AssetId, err)
+	}
+	log.Info("devices", "Updated asset %v of device %v from '%v' to '%v' under asset %v", confDevice.AssetId, confDevice.DeviceId, confDevice.AssetName, assetname, parentAssetId)
+	confDevice.AssetName = assetname
+	confDevice.ParentAssetId = parentAssetId
+	err = st.UpdateDevice(ctx, *confDevice)
+	if err != nil {
+		return fmt.Errorf("updating mapping: %w", err)
+	}
+	return nil
+}
+
+// Creates a new asset for a mapping whose asset was deleted in Eliona
+func recreateAsset(ctx context.Context, st store, el eliona.Api, assetname string, parentAssetId int32, confDevice *apiserver.Device) (*apiserver.Device, bool, error) {
+	assetId, err := eliona.UpsertDeviceAsset(ctx, el, confDevice.ProjectId, deviceGlobalAssetId(int64(confDevice.ConfigId), confDevice.DeviceId), assetname, parentAssetId)
+	if err != nil {
+		return nil, false, err
+	}
+	log.Info("devices", "Recreated asset %v for device %v, replacing deleted asset %v", assetId, confDevice.DeviceId, confDevice.AssetId)
+	// The alarm rules were deleted with the asset and are created again for the new one
+	if err := st.DeleteAlarmRules(ctx, confDevice.AssetId); err != nil {
+		return nil, false, fmt.Errorf("deleting alarm rules: %w", err)
+	}
+	confDevice.AssetId = assetId
+	confDevice.State = conf.DeviceStateActive
+	confDevice.AssetName = assetname
+	confDevice.ParentAssetId = parentAssetId
+	err = st.UpdateDevice(ctx, *confDevice)
+	if err != nil {
+		return nil, false, fmt.Errorf("updating mapping: %w", err)
+	}
+	return confDevice, true, nil
 }
 
-func createAssetandMapping(config apiserver.Configuration, projId string, deviceid string, assetname string, locationId string) (*apiserver.Device, error) {
-	assetId, err := eliona.CreateNewAsset(projId, deviceid, assetname)
+func createAssetandMapping(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, projId string, device glutz.DeviceDb, assetname string, parentAssetId int32) (*apiserver.Device, error) {
+	assetId, err := eliona.UpsertDeviceAsset(ctx, el, projId, deviceGlobalAssetId(config.ConfigId, device.DeviceId), assetname, parentAssetId)
 	if err != nil {
 		log.Error("devices", "Error when creating new asset")
 		return nil, err
 	}
 	log.Debug("devices", "AssetId %v assigned to device %v", assetId, assetname)
-	err = conf.InsertSpace(context.Background(), config.ConfigId, projId, deviceid, assetId, locationId)
+	err = st.InsertDevice(ctx, apiserver.Device{
+		ConfigId:      int32(config.ConfigId),
+		ProjectId:     projId,
+		DeviceId:      device.DeviceId,
+		AssetId:       assetId,
+		LocationId:    device.AccessPointId,
+		State:         conf.DeviceStateActive,
+		AssetName:     assetname,
+		ParentAssetId: parentAssetId,
+	})
 	if err != nil {
 		log.Error("devices", "Error when inserting device into database:%v", err)
 		return nil, err
 	}
 	log.Debug("devices", "Asset with AssetId %v corresponding to device %v inserted into eliona database", assetId, assetname)
-	confDevice, err := conf.GetDevice(context.Background(), config.ConfigId, projId, deviceid)
+	confDevice, err := st.GetDevice(ctx, config.ConfigId, projId, device.DeviceId)
 	if err != nil {
 		log.Error("devices", "Error when reading devices from configurations")
 		return nil, err
@@ -221,308 +817,736 @@ func createAssetandMapping(config apiserver.Configuration, projId string, device
 }
 
 // Upserts Input and Info Data to Eliona
-func sendData(Devices []glutz.DeviceDb, device int, confDevice *apiserver.Device) error {
-	err := eliona.UpsertInputData(Devices[device], confDevice.AssetId)
+func sendData(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, devices glutz.DevicesDb, confDevice *apiserver.Device) error {
+	device, err := deviceForMapping(devices, confDevice)
 	if err != nil {
 		return err
 	}
-	eliona.UpsertInfoData(Devices[device], confDevice.AssetId)
+	increase, err := communicationErrorsIncrease(ctx, st, device, confDevice)
+	if err != nil {
+		return err
+	}
+	health := eliona.DeviceHealth{
+		CommunicationErrorsIncrease: increase,
+		Online:                      isOnline(config, device, time.Now()),
+	}
+	err = eliona.UpsertInputData(ctx, el, device, health, confDevice.AssetId)
+	if err != nil {
+		return err
+	}
+	err = eliona.UpsertInfoData(ctx, el, device, confDevice.AssetId)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-// Glutz API request to get all devices in configuration
-func GetDevices(config apiserver.Configuration) (*glutz.DeviceGlutz, error) {
+// Returns the number of communication errors since the last refresh and stores the current counter in the
+// mapping. A counter lower than the stored one was reset on the device. Without a stored counter, e.g. for
+// new mappings, the increase is 0.
+func communicationErrorsIncrease(ctx context.Context, st store, device glutz.DeviceDb, confDevice *apiserver.Device) (int64, error) {
+	var increase int64
+	if last := confDevice.CommunicationErrors; last != nil {
+		if *last == device.CommunicationErrors {
+			return 0, nil
+		}
+		increase = device.CommunicationErrors - *last
+		if increase < 0 {
+			increase = device.CommunicationErrors
+		}
+	}
+	confDevice.CommunicationErrors = common.Ptr(device.CommunicationErrors)
+	if err := st.UpdateDevice(ctx, *confDevice); err != nil {
+		return 0, fmt.Errorf("storing communication errors: %w", err)
+	}
+	return increase, nil
+}
 
-	deviceRequest := Request{
-		Jsonrpc: "2.0",
-		ID:      "m",
-		Method:  "eAccess.getModel",
-		Params: []interface{}{
-			"Devices",
-		},
+// Devices whose last update couldn't be read. The warning is logged once per device, until the last update
+// can be read again.
+var unreadableLastUpdates = struct {
+	sync.Mutex
+	devices map[string]bool
+}{devices: make(map[string]bool)}
+
+// Returns false if the last update of the device is older than the stale threshold. Devices are
+// considered online if the Glutz server reports no or an unreadable last update.
+func isOnline(config apiserver.Configuration, device glutz.DeviceDb, now time.Time) bool {
+	if device.LastUpdate == "" {
+		return true
 	}
-	devicerequest, err := http.NewPostRequest(config.Url+"/rpc", deviceRequest)
+	lastUpdate, err := glutz.ParseLastUpdate(device.LastUpdate, conf.TimeZone(config))
+	key := fmt.Sprintf("%d/%s", config.ConfigId, device.DeviceId)
+	unreadableLastUpdates.Lock()
+	defer unreadableLastUpdates.Unlock()
 	if err != nil {
-		log.Error("devices", "Error with request: %v", err)
-		return nil, err
+		if !unreadableLastUpdates.devices[key] {
+			unreadableLastUpdates.devices[key] = true
+			log.Warn("devices", "Ignoring last update of device %s of config %d: %v", device.DeviceId, config.ConfigId, err)
+		}
+		return true
 	}
-	devicerequest.Header.Add("Referer", config.Url)
-	devicerequest.SetBasicAuth(config.Username, config.Password)
-	deviceList, err := http.Read[glutz.DeviceGlutz](devicerequest, time.Duration(time.Duration.Seconds(1)), true)
+	delete(unreadableLastUpdates.devices, key)
+	return now.Sub(lastUpdate) <= conf.StaleThreshold(config)
+}
+
+// Creates the alarm rules of the device asset in Eliona, or updates them if the thresholds of the config changed
+func syncAlarmRules(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, assetId int32) error {
+	stored, err := st.GetAlarmRules(ctx, assetId)
 	if err != nil {
-		log.Error("devices", "Error reading devices: %v", err)
-		return nil, err
+		return fmt.Errorf("reading alarm rules: %w", err)
+	}
+	byAttribute := make(map[string]conf.AlarmRule)
+	for _, rule := range stored {
+		byAttribute[rule.Attribute] = rule
+	}
+	for _, rule := range eliona.DeviceAlarmRules(assetId, conf.BatteryLowThreshold(config), conf.CommunicationErrorsThreshold(config), conf.StaleThreshold(config)) {
+		existing, ok := byAttribute[rule.Attribute]
+		if ok && existing.Threshold == rule.Threshold {
+			continue
+		}
+		if ok {
+			rule.Id = *api.NewNullableInt32(common.Ptr(existing.AlarmRuleId))
+		}
+		alarmRuleId, err := el.UpsertAlarmRule(ctx, rule.AlarmRule)
+		if err != nil {
+			return fmt.Errorf("upserting alarm rule for %s: %w", rule.Attribute, err)
+		}
+		err = st.UpsertAlarmRule(ctx, conf.AlarmRule{AssetId: assetId, Attribute: rule.Attribute, AlarmRuleId: alarmRuleId, Threshold: rule.Threshold})
+		if err != nil {
+			return fmt.Errorf("storing alarm rule for %s: %w", rule.Attribute, err)
+		}
+		log.Debug("devices", "Alarm rule %v for %v of asset %v upserted", alarmRuleId, rule.Attribute, assetId)
+	}
+	return nil
+}
+
+// Returns the data of the Glutz device the mapping refers to
+func deviceForMapping(devices glutz.DevicesDb, confDevice *apiserver.Device) (glutz.DeviceDb, error) {
+	device, ok := devices[confDevice.DeviceId]
+	if !ok {
+		return glutz.DeviceDb{}, fmt.Errorf("no data for device %s of asset %d", confDevice.DeviceId, confDevice.AssetId)
+	}
+	return device, nil
+}
+
+// Delay before the output listener is restarted after it exited
+const outputListenerRestartDelay = 5 * time.Second
+
+// Generates a websocket connection to the database and listens for any updates on assets (only output attributes). For any update written to the channel
+// the function checks whether the assetid of the update is associated with a glutz access point and opens, closes, holds open or releases it. After the "openable duration" time is up, the door
+// is closed again by closeOpenedDoors. If the door is currently open, a request to open it again will be ignored.
+// The updates are handled concurrently by the outputDispatcher, so a slow Glutz server doesn't delay the doors of other configurations.
+// The function works as watchdog: whenever the listener exits, e.g. because the websocket can't be connected, it is restarted.
+func listenForOutputChanges() {
+	dispatcher := newAccessPointDispatcher(confStore{}, newElionaClient)
+	dispatcher.start(outputWorkers)
+	for restarts := 0; ; restarts++ {
+		err := listenOutputs(newOutputWebSocket, dispatcher.dispatch)
+		log.Error("Output", "Output listener exited (%d restarts): %v", restarts, err)
+		time.Sleep(outputListenerRestartDelay)
 	}
-	return &deviceList, nil
 }
 
-// Glutz API request to initialize the access point property "openable duration" on the Glutz server
-func setAccessPointPropertyOpenableDuration(config apiserver.Configuration) (bool, error) {
-	req := Request{
-		Jsonrpc: "2.0",
-		ID:      "m",
-		Method:  "eAccess.setAccessPointProperty",
-		Params: []interface{}{
-			"/Properties/Eliona/Openable Duration [s]",
-			"",
-			"0",
+// Creates the dispatcher which resolves the access point mapped to the asset of an output and opens, closes,
+// holds open or releases it. Each request is recorded as command.
+func newAccessPointDispatcher(st store, elionaFor func(apiserver.Configuration) eliona.Api) *outputDispatcher {
+	return newOutputDispatcher(
+		func(ctx context.Context, output api.Data) (*apiserver.AccessPoint, *apiserver.Configuration, error) {
+			return getAccessPointAndGetConfig(ctx, st, output)
 		},
+		func(ctx context.Context, accessPoint apiserver.AccessPoint, config apiserver.Configuration, output api.Data) {
+			handleAccessPointOutput(ctx, st, elionaFor, accessPoint, config, output)
+		})
+}
+
+func newOutputWebSocket() (*websocket.Conn, error) {
+	return http.NewWebSocketConnectionWithApiKey(common.Getenv("API_ENDPOINT", "")+"/data-listener?dataSubtype=output", "X-API-Key", common.Getenv("API_TOKEN", ""))
+}
+
+// Handles each output received from the websocket until the websocket listener returns. A panic while handling
+// an output is logged and the next output is handled.
+func listenOutputs(newWebSocket func() (*websocket.Conn, error), handle func(api.Data)) error {
+	outputs := make(chan api.Data)
+	done := make(chan error, 1)
+	go func() {
+		done <- http.ListenWebSocketWithReconnect(newWebSocket, 50*time.Millisecond, outputs)
+	}()
+	for {
+		select {
+		case output := <-outputs:
+			handleOutputSafely(handle, output)
+		case err := <-done:
+			if err == nil {
+				err = fmt.Errorf("websocket closed")
+			}
+			return err
+		}
 	}
-	accesspointrequest, err := http.NewPostRequest(config.Url+"/rpc", req)
-	if err != nil {
-		log.Error("devices", "Error with request: %v", err)
-		return false, err
+}
+
+func handleOutputSafely(handle func(api.Data), output api.Data) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Output", "Panic handling output of asset %d: %v", output.AssetId, r)
+		}
+	}()
+	handle(output)
+}
+
+// Closes the doors whose openable duration is over
+func closeOpenedDoors() {
+	openedDoors.closeDue(context.Background(), confStore{}, newElionaClient, time.Now())
+}
+
+var openedDoors doorCloser
+
+func newElionaClient(config apiserver.Configuration) eliona.Api {
+	return eliona.NewClient(conf.RequestTimeout(config))
+}
+
+func newGlutzClient(config apiserver.Configuration) *glutz.Client {
+	return glutz.NewClient(config, conf.RequestTimeout(config), conf.BatchSize(config))
+}
+
+// Opens, closes, holds open or releases the given access point as requested by the output
+func handleAccessPointOutput(ctx context.Context, st store, elionaFor func(apiserver.Configuration) eliona.Api, accessPoint apiserver.AccessPoint, config apiserver.Configuration, output api.Data) {
+	action, err := outputAction(output)
+	if err != nil || action == "" {
+		return
 	}
-	accesspointrequest.Header.Add("Referer", config.Url)
-	accesspointrequest.SetBasicAuth(config.Username, config.Password)
-	propertyset, err := http.Read[glutz.Properties](accesspointrequest, time.Duration(time.Duration.Seconds(1)), true)
-	if err != nil {
-		log.Error("devices", "Error setting access point property: %v", err)
-		return false, err
+	command := conf.Command{
+		ConfigId:      config.ConfigId,
+		AssetId:       accessPoint.AssetId,
+		AccessPointId: accessPoint.AccessPointId,
+		Action:        action,
+		State:         conf.CommandStateRequested,
+		RequestedAt:   time.Now(),
+	}
+	var doorStateErr error
+	if action == conf.CommandActionOpen {
+		var openableDoor bool
+		openableDoor, doorStateErr = checkThereIsADoorToBeOpened(ctx, st, accessPoint)
+		if doorStateErr == nil && !openableDoor {
+			command.State = conf.CommandStateIgnored
+			command.Error = "door is already opening or open"
+		}
+	}
+	if err := st.InsertCommand(ctx, &command); err != nil {
+		log.Error("Output", "Error storing %s command for access point %s: %v", action, accessPoint.AccessPointId, err)
+		return
+	}
+	if command.State == conf.CommandStateIgnored {
+		return
+	}
+	el := elionaFor(config)
+	if doorStateErr != nil {
+		setCommandFailed(&command, fmt.Errorf("reading door state: %w", doorStateErr))
+		writeOpenable(ctx, el, &command, openableFailed)
+		updateCommand(ctx, st, command)
+		return
+	}
+	client := newGlutzClient(config)
+	setCommandDoorState(ctx, st, command)
+	if action == conf.CommandActionOpen {
+		openableDuration, err := getOpenableDuration(ctx, client, &config, accessPoint.AccessPointId)
+		if err == nil && openableDuration <= 0 {
+			err = fmt.Errorf("openable duration %d is not positive", openableDuration)
+		}
+		if err != nil {
+			setCommandFailed(&command, fmt.Errorf("reading openable duration: %w", err))
+			writeOpenable(ctx, el, &command, openableFailed)
+			updateCommand(ctx, st, command)
+			return
+		}
+		command.Duration = int32(openableDuration)
+		openDoor(ctx, st, el, client, &command)
+	} else {
+		closeImmediately(ctx, st, el, client, &command)
 	}
-	return propertyset.Result, nil
 }
 
-// Glutz API request to get the value of the accesspoint property "openable duration" from the Glutz server
-func getAccessPointPropertyOpenableDuration(config apiserver.Configuration, locationid string) (string, error) {
-	req := Request{
-		Jsonrpc: "2.0",
-		ID:      "m",
-		Method:  "eAccess.getAccessPointProperty",
-		Params: []interface{}{
-			"/Properties/Eliona/Openable Duration [s]",
-			locationid,
-		},
+// Sends a close command to the Glutz server and records the outcome. The door is closed immediately and the
+// open commands of the access point still active are finished.
+func closeImmediately(ctx context.Context, st store, el eliona.Api, client *glutz.Client, command *conf.Command) {
+	sentAt := time.Now()
+	command.State = conf.CommandStateSent
+	command.SentAt = &sentAt
+	if !updateCommand(ctx, st, *command) {
+		return
+	}
+	closed, err := sendOpenableDurationToDoor(ctx, client, 0, command.AccessPointId)
+	if err == nil && !closed {
+		err = fmt.Errorf("rejected by the Glutz server")
 	}
-	accesspointrequest, err := http.NewPostRequest(config.Url+"/rpc", req)
 	if err != nil {
-		log.Error("devices", "Error with request: %v", err)
-		return "", err
+		setCommandFailed(command, fmt.Errorf("closing: %w", err))
+		writeOpenable(ctx, el, command, openableFailed)
+		updateCommand(ctx, st, *command)
+		return
 	}
-	accesspointrequest.Header.Add("Referer", config.Url)
-	accesspointrequest.SetBasicAuth(config.Username, config.Password)
-	propertyget, err := http.Read[glutz.GlutzOpenableDuration](accesspointrequest, time.Duration(time.Duration.Seconds(1)), true)
+	finishActiveCommands(ctx, st, *command)
+	closedAt := time.Now()
+	command.State = conf.CommandStateClosed
+	command.ClosedAt = &closedAt
+	writeOpenable(ctx, el, command, openableClosed)
+	updateCommand(ctx, st, *command)
+	log.Debug("Output", "Closed door at Location %v immediately", command.AccessPointId)
+}
+
+// Finishes the open commands of the access point which are replaced by the given command, so that
+// closeDueDoors doesn't close the door again later
+func finishActiveCommands(ctx context.Context, st store, command conf.Command) {
+	commands, err := st.GetCommands(ctx, conf.CommandStateOpened)
 	if err != nil {
-		log.Error("devices", "Error reading device status: %v", err)
-		return "", err
+		log.Error("Output", "Error reading opened commands: %v", err)
+		return
+	}
+	for _, active := range commands {
+		if active.CommandId == command.CommandId || active.ConfigId != command.ConfigId || active.AccessPointId != command.AccessPointId {
+			continue
+		}
+		closedAt := time.Now()
+		active.State = conf.CommandStateClosed
+		active.ClosedAt = &closedAt
+		updateCommand(ctx, st, active)
 	}
-	return propertyget.Result, nil
 }
 
-// Glutz API request to get device status of a specific Glutz device
-func GetDeviceStatus(config apiserver.Configuration, device_id string) (*glutz.DeviceStatusGlutz, error) {
-	req := Request{
-		Jsonrpc: "2.0",
-		ID:      "m",
-		Method:  "eAccess.getModel",
-		Params: []interface{}{
-			"DeviceStatus",
-			DeviceParams{DeviceID: device_id},
-		},
+// Sends the open request of the command to the Glutz server and records the outcome
+func openDoor(ctx context.Context, st store, el eliona.Api, client *glutz.Client, command *conf.Command) {
+	sentAt := time.Now()
+	command.State = conf.CommandStateSent
+	command.SentAt = &sentAt
+	if !updateCommand(ctx, st, *command) {
+		return
 	}
-	devicestatusrequest, err := http.NewPostRequest(config.Url+"/rpc", req)
-	if err != nil {
-		log.Error("devices", "Error with request: %v", err)
-		return nil, err
+	opened, err := sendOpenableDurationToDoor(ctx, client, int(command.Duration), command.AccessPointId)
+	if err == nil && !opened {
+		err = fmt.Errorf("rejected by the Glutz server")
 	}
-	devicestatusrequest.Header.Add("Referer", config.Url)
-	devicestatusrequest.SetBasicAuth(config.Username, config.Password)
-	deviceStatus, err := http.Read[glutz.DeviceStatusGlutz](devicestatusrequest, time.Duration(time.Duration.Seconds(1)), true)
 	if err != nil {
-		log.Error("devices", "Error reading device status: %v", err)
-		return nil, err
+		log.Debug("Output", "Could not open door at Location %v for %v seconds", command.AccessPointId, command.Duration)
+		setCommandFailed(command, fmt.Errorf("opening: %w", err))
+		writeOpenable(ctx, el, command, openableFailed)
+		updateCommand(ctx, st, *command)
+		return
 	}
-	return &deviceStatus, nil
+	openedAt := time.Now()
+	closeAt := openedAt.Add(time.Duration(command.Duration) * time.Second)
+	command.State = conf.CommandStateOpened
+	command.OpenedAt = &openedAt
+	command.CloseAt = &closeAt
+	writeOpenable(ctx, el, command, openableOpen)
+	if !updateCommand(ctx, st, *command) {
+		// closeDueDoors doesn't find the door as the command is not stored as opened. The door state is
+		// set anyway and the door is closed after the openable duration, storing the command again.
+		log.Error("Output", "Door at Location %v opened without storing command %d, closing it in %v seconds", command.AccessPointId, command.CommandId, command.Duration)
+		setCommandDoorState(ctx, st, *command)
+		opened := *command
+		time.AfterFunc(closeAt.Sub(openedAt), func() {
+			closeDoor(context.Background(), st, func(apiserver.Configuration) eliona.Api { return el }, &opened)
+		})
+		return
+	}
+	log.Debug("Output", "Opened door at Location %v for %v seconds", command.AccessPointId, command.Duration)
 }
 
-// Glutz API request to get device status of a specific Glutz device
-func GetLocation(config apiserver.Configuration, accessPointId string) (*glutz.DeviceAccessPointGlutz, error) {
-	req := Request{
-		Jsonrpc: "2.0",
-		ID:      "m",
-		Method:  "eAccess.getAccessPointProperty",
-		Params: []interface{}{
-			"location",
-			accessPointId,
-		},
+// Delays of the next try to close a door after closing failed, doubled with each error
+const (
+	closeRetryDelay    = 5 * time.Second
+	closeRetryDelayMax = 10 * time.Minute
+)
+
+// doorCloser closes the doors of opened commands whose openable duration is over. The doors of each
+// configuration are closed in their own goroutine. A configuration whose doors are still being closed, e.g.
+// as its Glutz server doesn't answer, is skipped without delaying the doors of the other configurations.
+type doorCloser struct {
+	mu      sync.Mutex
+	closing map[int64]bool
+	wg      sync.WaitGroup
+}
+
+// Starts closing the due doors of all configurations not being closed yet. The door is closed by the app as
+// it seems the Glutz API doesn't take the openable duration into account.
+func (d *doorCloser) closeDue(ctx context.Context, st store, elionaFor func(apiserver.Configuration) eliona.Api, now time.Time) {
+	commands, err := st.GetCommands(ctx, conf.CommandStateOpened)
+	if err != nil {
+		log.Error("Output", "Error reading opened commands: %v", err)
+		return
+	}
+	due := make(map[int64][]conf.Command)
+	for _, command := range commands {
+		if command.CloseAt == nil || command.CloseAt.After(now) {
+			continue
+		}
+		due[command.ConfigId] = append(due[command.ConfigId], command)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closing == nil {
+		d.closing = make(map[int64]bool)
+	}
+	for configId, commands := range due {
+		if d.closing[configId] {
+			continue
+		}
+		d.closing[configId] = true
+		d.wg.Add(1)
+		go func(configId int64, commands []conf.Command) {
+			defer d.wg.Done()
+			for i := range commands {
+				closeDoor(ctx, st, elionaFor, &commands[i])
+			}
+			d.mu.Lock()
+			delete(d.closing, configId)
+			d.mu.Unlock()
+		}(configId, commands)
 	}
-	deviceaccesspointrequest, err := http.NewPostRequest(config.Url+"/rpc", req)
+}
+
+// Waits until all doors being closed are closed or failed
+func (d *doorCloser) wait() {
+	d.wg.Wait()
+}
+
+// Closes the door of the opened command. If closing fails, the command stays opened and the door is closed
+// again later, as it may still be open. The delay of the next try doubles with each error.
+func closeDoor(ctx context.Context, st store, elionaFor func(apiserver.Configuration) eliona.Api, command *conf.Command) {
+	config, err := st.GetConfig(ctx, command.ConfigId)
 	if err != nil {
-		log.Error("devices", "Error with request: %v", err)
-		return nil, err
+		log.Error("Output", "Error getting configuration %v", err)
+		return
+	}
+	if config == nil {
+		setCommandFailed(command, fmt.Errorf("configuration %d no longer exists", command.ConfigId))
+		updateCommand(ctx, st, *command)
+		return
+	}
+	el := elionaFor(*config)
+	err = conf.PasswordError(*config)
+	if err == nil {
+		var closed bool
+		closed, err = sendOpenableDurationToDoor(ctx, newGlutzClient(*config), 0, command.AccessPointId)
+		if err == nil && !closed {
+			err = fmt.Errorf("rejected by the Glutz server")
+		}
 	}
-	deviceaccesspointrequest.Header.Add("Referer", config.Url)
-	deviceaccesspointrequest.SetBasicAuth(config.Username, config.Password)
-	deviceAccessPoint, err := http.Read[glutz.DeviceAccessPointGlutz](deviceaccesspointrequest, time.Duration(time.Duration.Seconds(1)), true)
 	if err != nil {
-		log.Error("devices", "Error reading device access point: %v", err)
-		return nil, err
+		recordCommandError(command, fmt.Errorf("closing: %w", err))
+		retryAt := time.Now().Add(closeRetryDelayAfter(command.ErrorCount))
+		command.State = conf.CommandStateOpened
+		command.CloseAt = &retryAt
+		writeOpenable(ctx, el, command, openableFailed)
+		updateCommand(ctx, st, *command)
+		return
 	}
-	return &deviceAccessPoint, nil
+	closedAt := time.Now()
+	command.State = conf.CommandStateClosed
+	command.ClosedAt = &closedAt
+	writeOpenable(ctx, el, command, openableClosed)
+	updateCommand(ctx, st, *command)
+	log.Debug("Output", "Closed door at Location %v again", command.AccessPointId)
 }
 
-// Generates a websocket connection to the database and listens for any updates on assets (only output attributes). For any update written to the channel
-// the function checks whether the assetid of the update is associated with a glutz device and opens it. After the "openable duration" time is up, the door
-// is closed again. If the door is currently open, a request to open it again will be ignored.
-func listenForOutputChanges() {
-	outputs := make(chan api.Data)
-	go http.ListenWebSocketWithReconnect(func() (*websocket.Conn, error) {
-		return http.NewWebSocketConnectionWithApiKey(common.Getenv("API_ENDPOINT", "")+"/data-listener?dataSubtype=output", "X-API-Key", common.Getenv("API_TOKEN", ""))
-	}, 50*time.Millisecond, outputs)
-	for output := range outputs {
-		openableDoor, _ := checkThereIsADoorToBeOpened(output)
-		if openableDoor {
-			device, config, _ := getDeviceAndGetConfig(output)
-			if device != nil && config != nil {
-				openableDuration, _ := getOpenableDuration(config, device)
-				if openableDuration > 0 {
-					response, _ := sendOpenableDurationToDoor(*config, int(openableDuration), device.LocationId)
-					if response {
-						err := eliona.UpsertOpenData(1, device.AssetId)
-						if err != nil {
-							return
-						}
-						log.Debug("Output", "Opened door at Location %v for %v seconds", device.LocationId, openableDuration)
-						go waitAndResetOpen(*config, int(openableDuration), device.AssetId, device.LocationId)
-					}
-					if !response {
-						log.Debug("Output", "Could not open door at Location %v for %v seconds", device.LocationId, openableDuration)
-						err := eliona.UpsertOpenData(2, device.AssetId)
-						if err != nil {
-							return
-						}
-					}
-				}
+// Delay of the next try to close a door after the given number of errors
+func closeRetryDelayAfter(errorCount int32) time.Duration {
+	delay := closeRetryDelay
+	for i := int32(1); i < errorCount && delay < closeRetryDelayMax; i++ {
+		delay *= 2
+	}
+	if delay > closeRetryDelayMax {
+		return closeRetryDelayMax
+	}
+	return delay
+}
+
+// Recovers the commands interrupted by a restart of the app. Requested commands were never sent to the Glutz
+// server and fail. The doors of sent commands may be open, they are closed after their openable duration like
+// the doors of opened commands.
+func recoverCommands(ctx context.Context, st store) {
+	commands, err := st.GetCommands(ctx, conf.CommandStateRequested, conf.CommandStateSent)
+	if err != nil {
+		log.Error("Output", "Error reading interrupted commands: %v", err)
+		return
+	}
+	for i := range commands {
+		command := &commands[i]
+		switch command.State {
+		case conf.CommandStateRequested:
+			setCommandFailed(command, fmt.Errorf("interrupted before sending"))
+		case conf.CommandStateSent:
+			if command.Action != "" && command.Action != conf.CommandActionOpen {
+				// The door may be closed or not, it is closed again by its open command
+				setCommandFailed(command, fmt.Errorf("interrupted after sending"))
+				break
 			}
+			sentAt := command.RequestedAt
+			if command.SentAt != nil {
+				sentAt = *command.SentAt
+			}
+			closeAt := sentAt.Add(time.Duration(command.Duration) * time.Second)
+			command.State = conf.CommandStateOpened
+			command.CloseAt = &closeAt
 		}
+		updateCommand(ctx, st, *command)
+	}
+	if len(commands) > 0 {
+		log.Info("Output", "Recovered %d interrupted commands", len(commands))
 	}
+	recoverDoorStates(ctx, st)
 }
 
-// Checks if the assetid corresponds to a glutz device and that the value written to open is 1
-func checkThereIsADoorToBeOpened(output api.Data) (bool, error) {
-	DeviceExists, err := conf.ExistGlutzDeviceWithAssetId(context.Background(), output.AssetId)
+// Closes the doors left opening or open without an active command, e.g. if the app stopped before the
+// door state of a finished command was stored
+func recoverDoorStates(ctx context.Context, st store) {
+	doorStates, err := st.GetDoorStates(ctx, conf.DoorStateOpening, conf.DoorStateOpen)
 	if err != nil {
-		log.Error("Output", "Error checking if asset id corresponds to a glutz device")
-		return false, err
+		log.Error("Output", "Error reading door states: %v", err)
+		return
 	}
-	data, err := mapToStruct(output.Data)
+	commands, err := st.GetCommands(ctx, conf.CommandStateRequested, conf.CommandStateSent, conf.CommandStateOpened)
 	if err != nil {
-		log.Error("Output", "Error converting map to struct")
-		return false, err
+		log.Error("Output", "Error reading active commands: %v", err)
+		return
+	}
+	active := make(map[int64]bool)
+	for _, command := range commands {
+		active[command.CommandId] = true
+	}
+	for _, doorState := range doorStates {
+		if active[doorState.CommandId] {
+			continue
+		}
+		// The door is only closed if its state wasn't changed since it was read
+		updateDoorState(ctx, st, doorState.ConfigId, doorState.AccessPointId, func(current conf.DoorState) (conf.DoorState, bool) {
+			if current.State != doorState.State || current.CommandId != doorState.CommandId {
+				return current, false
+			}
+			current.State = conf.DoorStateClosed
+			current.ChangedAt = time.Now()
+			return current, true
+		})
+	}
+}
+
+func setCommandFailed(command *conf.Command, err error) {
+	failedAt := time.Now()
+	command.State = conf.CommandStateFailed
+	command.FailedAt = &failedAt
+	recordCommandError(command, err)
+}
+
+// Logs and counts an error of the command. Errors which don't fail the command, e.g. writing the openable
+// state to Eliona, are recorded as well.
+func recordCommandError(command *conf.Command, err error) {
+	log.Error("Output", "Command %d for access point %s: %v", command.CommandId, command.AccessPointId, err)
+	command.Error = err.Error()
+	command.ErrorCount++
+}
+
+func updateCommand(ctx context.Context, st store, command conf.Command) bool {
+	if err := st.UpdateCommand(ctx, command); err != nil {
+		log.Error("Output", "Error storing command %d: %v", command.CommandId, err)
+		return false
+	}
+	setCommandDoorState(ctx, st, command)
+	return true
+}
+
+// Door state after the command changed its state. A door closed immediately is closed. For open commands only
+// the command which opened the door closes it again, and the office mode overrides them. Ignored commands
+// don't change the door state.
+func commandDoorState(current conf.DoorState, command conf.Command) string {
+	if command.State == conf.CommandStateIgnored {
+		return current.State
+	}
+	if command.Action == conf.CommandActionClose {
+		if command.State == conf.CommandStateClosed {
+			return conf.DoorStateClosed
+		}
+		return current.State
+	}
+	if current.State == conf.DoorStatePermanentlyOpen {
+		return current.State
+	}
+	switch command.State {
+	case conf.CommandStateRequested, conf.CommandStateSent:
+		return conf.DoorStateOpening
+	case conf.CommandStateOpened:
+		return conf.DoorStateOpen
+	}
+	if current.CommandId != 0 && current.CommandId != command.CommandId {
+		return current.State
+	}
+	return conf.DoorStateClosed
+}
+
+// Door state after the Glutz server reported whether a device of the access point is in office mode
+func reportedDoorState(current string, officeMode bool) string {
+	if officeMode {
+		return conf.DoorStatePermanentlyOpen
 	}
-	open := data.Open
-	doorAlreadyOpen, err := checkDoorIfDoorIsAlreadyOpen(output.AssetId)
+	if current == conf.DoorStatePermanentlyOpen {
+		return conf.DoorStateClosed
+	}
+	return current
+}
+
+func setCommandDoorState(ctx context.Context, st store, command conf.Command) {
+	updateDoorState(ctx, st, command.ConfigId, command.AccessPointId, func(current conf.DoorState) (conf.DoorState, bool) {
+		state := commandDoorState(current, command)
+		if state == current.State {
+			return current, false
+		}
+		return conf.DoorState{
+			ConfigId:      command.ConfigId,
+			AccessPointId: command.AccessPointId,
+			State:         state,
+			CommandId:     command.CommandId,
+			ChangedAt:     time.Now(),
+		}, true
+	})
+}
+
+// Updates the door states of all access points from the operating modes reported by their devices. If the
+// door state changes, openable of the access point assets is set to permanently open or closed.
+func syncReportedDoorStates(ctx context.Context, st store, el eliona.Api, config apiserver.Configuration, devices glutz.DevicesDb, cycle *syncCycle) {
+	officeMode := make(map[string]bool)
+	for _, device := range devices {
+		officeMode[device.AccessPointId] = officeMode[device.AccessPointId] || device.OperatingMode == glutz.OperatingModeOffice
+	}
+	mappings, err := st.GetAccessPoints(ctx, config.ConfigId)
 	if err != nil {
-		log.Error("Output", "Error checking whether door is already open")
-		return false, err
+		cycle.errors = append(cycle.errors, fmt.Errorf("reading access point mappings: %w", err))
+		return
 	}
-	if !DeviceExists || open != 1 || doorAlreadyOpen {
-		return false, nil
+	for accessPointId, office := range officeMode {
+		doorState, changed := updateDoorState(ctx, st, config.ConfigId, accessPointId, func(current conf.DoorState) (conf.DoorState, bool) {
+			state := reportedDoorState(current.State, office)
+			if state == current.State {
+				return current, false
+			}
+			return conf.DoorState{
+				ConfigId:      config.ConfigId,
+				AccessPointId: accessPointId,
+				State:         state,
+				ChangedAt:     time.Now(),
+			}, true
+		})
+		if !changed {
+			continue
+		}
+		openable := openableClosed
+		if doorState.State == conf.DoorStatePermanentlyOpen {
+			openable = openablePermanentlyOpen
+		}
+		for _, mapping := range mappings {
+			if mapping.AccessPointId != accessPointId || mapping.State == conf.DeviceStateUserDeleted {
+				continue
+			}
+			if err := eliona.UpsertOpenData(ctx, el, openable, mapping.AssetId); err != nil {
+				cycle.errors = append(cycle.errors, fmt.Errorf("access point %s in project %s: writing openable %d: %w", accessPointId, mapping.ProjectId, openable, err))
+			}
+		}
 	}
-	return true, nil
 }
 
-// Checks if a door is opened by reading the "openable" attribute for the asset with the given assetid.
-func checkDoorIfDoorIsAlreadyOpen(assetid int32) (bool, error) {
-	request, err := http.NewRequestWithApiKey(common.Getenv("API_ENDPOINT", "")+"/data?assetId="+strconv.Itoa(int(assetid))+"&dataSubtype=input", "X-API-KEY", common.Getenv("API_TOKEN", ""))
+// Changes the door state of the access point with update, see store.UpdateDoorState, and logs the change.
+// Returns the door state and whether it was changed.
+func updateDoorState(ctx context.Context, st store, configId int64, accessPointId string, update func(current conf.DoorState) (conf.DoorState, bool)) (conf.DoorState, bool) {
+	doorState, changed, err := st.UpdateDoorState(ctx, configId, accessPointId, update)
 	if err != nil {
-		log.Error("Output", "Error with request: %v", err)
-		return false, err
+		log.Error("Output", "Error storing door state of access point %s: %v", accessPointId, err)
+		return doorState, false
+	}
+	if changed {
+		log.Debug("Output", "Door at access point %s is %s", accessPointId, doorState.State)
+	}
+	return doorState, changed
+}
+
+// Writes the openable state of the access point of the command: 1 while open, 0 when closed again, 2 if
+// opening or closing failed
+func writeOpenable(ctx context.Context, el eliona.Api, command *conf.Command, openable int32) {
+	if err := eliona.UpsertOpenData(ctx, el, openable, command.AssetId); err != nil {
+		recordCommandError(command, fmt.Errorf("writing openable %d: %w", openable, err))
 	}
-	asset_data, err := http.Read[glutz.AssetData](request, time.Duration(time.Duration.Seconds(1)), true)
+}
+
+// Returns the command action requested by the value written to open. Zero and unknown values request no action.
+func outputAction(output api.Data) (string, error) {
+	data, err := mapToStruct(output.Data)
 	if err != nil {
-		log.Error("Output", "Error reading asset data: %v", err)
+		log.Error("Output", "Error converting map to struct")
+		return "", err
+	}
+	action, ok := outputActions[data.Open]
+	if !ok && data.Open != 0 {
+		log.Debug("Output", "Ignoring unknown open value %v of asset %d", data.Open, output.AssetId)
+	}
+	return action, nil
+}
+
+// Checks that the door of the access point is not opening or open by a command of the app. Requests to open
+// such a door are ignored. A door reported permanently open is opened anyway, as the office mode of the
+// devices is assumed, see glutz.OperatingModeOffice.
+func checkThereIsADoorToBeOpened(ctx context.Context, st store, accessPoint apiserver.AccessPoint) (bool, error) {
+	doorState, err := st.GetDoorState(ctx, int64(accessPoint.ConfigId), accessPoint.AccessPointId)
+	if err != nil {
+		log.Error("Output", "Error reading door state of access point %s: %v", accessPoint.AccessPointId, err)
 		return false, err
 	}
-	if asset_data[0].Data.Openable == 1 {
-		log.Debug("Output", "Door is already open")
-		return true, nil
-	} else {
+	if doorState.State == conf.DoorStateOpening || doorState.State == conf.DoorStateOpen {
+		log.Debug("Output", "Door at access point %s is already %s", accessPoint.AccessPointId, doorState.State)
 		return false, nil
 	}
-
+	return true, nil
 }
 
-// Fetches the Glutz device where a value was changed in the database and the configuration
-func getDeviceAndGetConfig(output api.Data) (*apiserver.Device, *apiserver.Configuration, error) {
-	device, err := conf.GetDevicewithAssetId(context.Background(), output.AssetId)
+// Fetches the Glutz access point where a value was changed in the database and the configuration
+func getAccessPointAndGetConfig(ctx context.Context, st store, output api.Data) (*apiserver.AccessPoint, *apiserver.Configuration, error) {
+	accessPoint, err := st.GetAccessPointWithAssetId(ctx, output.AssetId)
 	if err != nil {
-		log.Error("Output", "Error getting device from assetid %v", err)
+		log.Error("Output", "Error getting access point from assetid %v", err)
 		return nil, nil, err
 	}
-	config, err := conf.GetConfig(context.Background(), int64(device.ConfigId))
+	if accessPoint == nil {
+		return nil, nil, nil
+	}
+	config, err := st.GetConfig(ctx, int64(accessPoint.ConfigId))
+	if err == nil && config != nil {
+		err = conf.PasswordError(*config)
+	}
 	if err != nil {
 		log.Error("Output", "Error getting configuration %v", err)
 		return nil, nil, err
 	}
-	return device, config, nil
+	return accessPoint, config, nil
 }
 
 // Check if a value exists in glutz environment for openable duration for this door. If so, use this value.
-// If not, use the default value from the config table
-func getOpenableDuration(config *apiserver.Configuration, device *apiserver.Device) (int, error) {
-	glutzOpenableDuration, err := getAccessPointPropertyOpenableDuration(*config, device.LocationId)
+// If not, use the default value from the config table. The app initializes the property with 0 on the Glutz
+// server, so 0 means the door has no own openable duration, too.
+func getOpenableDuration(ctx context.Context, client *glutz.Client, config *apiserver.Configuration, accessPointId string) (int, error) {
+	glutzOpenableDuration, err := client.GetAccessPointProperty(ctx, glutz.OpenableDurationProperty, accessPointId)
 	if err != nil {
-		log.Error("Output", "Error sending openable duration to door")
 		return 0, err
 	}
-	var openableDuration int
-	if glutzOpenableDuration != "" {
-		openableDuration, err = strconv.Atoi(glutzOpenableDuration)
-		if err != nil {
-			log.Error("Output", "Couldn't convert to integer %v", err)
-			return 0, err
-		}
-
-	} else {
-		openableDuration = int(config.DefaultOpenableDuration)
+	if glutzOpenableDuration == "" || glutzOpenableDuration == "0" {
+		return int(config.DefaultOpenableDuration), nil
 	}
-	return openableDuration, nil
+	return strconv.Atoi(glutzOpenableDuration)
 }
 
 // Opens/closes the door. Openable Duration isn't considered in the current Glutz API implementation
-func sendOpenableDurationToDoor(config apiserver.Configuration, openableDuration int, locationid string) (bool, error) {
-	durationstring := formatDuration(openableDuration)
-	req := Request{
-		Jsonrpc: "2.0",
-		ID:      "m",
-		Method:  "eAccess.openAccessPoint",
-		Params: []interface{}{
-			locationid,
-			Duration{Duration: durationstring},
-		},
-	}
-	setdurationrequest, err := http.NewPostRequest(config.Url+"/rpc", req)
+func sendOpenableDurationToDoor(ctx context.Context, client *glutz.Client, openableDuration int, locationid string) (bool, error) {
+	opened, err := client.OpenAccessPoint(ctx, locationid, time.Duration(openableDuration)*time.Second)
 	if err != nil {
-		log.Error("devices", "Error with request: %v", err)
+		log.Error("devices", "Error opening access point: %v", err)
 		return false, err
 	}
-	setdurationrequest.Header.Add("Referer", config.Url)
-	setdurationrequest.SetBasicAuth(config.Username, config.Password)
-	durationset, err := http.Read[glutz.Properties](setdurationrequest, time.Duration(time.Duration.Seconds(1)), true)
-	if err != nil {
-		log.Error("devices", "Error reading device status: %v", err)
-		return false, err
-	}
-	return durationset.Result, nil
-}
-
-// Waits until the time is ready to close door again. Then closes door.
-func waitAndResetOpen(config apiserver.Configuration, openableDuration int, assetid int32, locationid string) {
-	time.Sleep(time.Second * time.Duration(openableDuration))
-	// Here we close the door again automatically after the length of time "openable duration" as it seems
-	// the Glutz API doesn't take the time into account.
-	response, _ := sendOpenableDurationToDoor(config, 0, locationid)
-	if response {
-		eliona.UpsertOpenData(0, assetid)
-		log.Debug("Output", "Closed door at Location %v again", locationid)
-
-	} else {
-		eliona.UpsertOpenData(2, assetid)
-	}
+	return opened, nil
 }
 
 func mapToStruct(m map[string]interface{}) (*OutputData, error) {
@@ -537,18 +1561,13 @@ func mapToStruct(m map[string]interface{}) (*OutputData, error) {
 	return s, nil
 }
 
-func formatDuration(duration int) string {
-	hours := duration / 3600
-	minutes := (duration % 3600) / 60
-	seconds := duration % 60
-
-	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-}
+// Timeout of the requests to Eliona made by the API server, e.g. to check the projects of a configuration
+const apiRequestTimeout = 30 * time.Second
 
 func listenApiRequests() {
 	err := nethttp.ListenAndServe(":"+common.Getenv("API_SERVER_PORT", "3000"), utilshttp.NewCORSEnabledHandler(
 		apiserver.NewRouter(
-			apiserver.NewConfigurationApiController(apiservices.NewConfigurationApiService()),
+			apiserver.NewConfigurationApiController(apiservices.NewConfigurationApiService(eliona.NewClient(apiRequestTimeout))),
 			apiserver.NewVersionApiController(apiservices.NewVersionApiService()),
 			apiserver.NewCustomizationApiController(apiservices.NewCustomizationApiService()),
 			apiserver.NewDevicesApiController(apiservices.NewDevicesApiService()),
@@ -0,0 +1,1863 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"glutz/apiserver"
+	"glutz/conf"
+	"glutz/eliona"
+	"glutz/glutz"
+	"glutz/glutz/mock"
+	nethttp "net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+	"github.com/gorilla/websocket"
+)
+
+// memStore is an in-memory store for tests
+type memStore struct {
+	mu           sync.Mutex
+	configs      map[int64]apiserver.Configuration
+	devices      []apiserver.Device
+	accessPoints []apiserver.AccessPoint
+	alarmRules   []conf.AlarmRule
+	summaries    map[int64]apiserver.CycleSummary
+	commands     []conf.Command
+	doorStates   map[accessPointKey]conf.DoorState
+	migrated     map[int64]bool
+}
+
+func newMemStore(configs ...apiserver.Configuration) *memStore {
+	st := &memStore{
+		configs:    make(map[int64]apiserver.Configuration),
+		summaries:  make(map[int64]apiserver.CycleSummary),
+		doorStates: make(map[accessPointKey]conf.DoorState),
+		migrated:   make(map[int64]bool),
+	}
+	for _, config := range configs {
+		st.configs[config.ConfigId] = config
+	}
+	return st
+}
+
+func (s *memStore) GetConfig(_ context.Context, configId int64) (*apiserver.Configuration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config, ok := s.configs[configId]
+	if !ok {
+		return nil, nil
+	}
+	return &config, nil
+}
+
+func (s *memStore) SetConfigInitialisedState(_ context.Context, configId int64, state bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config := s.configs[configId]
+	config.Initialized = &state
+	s.configs[configId] = config
+	return nil
+}
+
+func (s *memStore) AreDeviceAssetsMigrated(_ context.Context, configId int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.migrated[configId], nil
+}
+
+func (s *memStore) SetDeviceAssetsMigrated(_ context.Context, configId int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.migrated[configId] = true
+	return nil
+}
+
+func (s *memStore) SetConfigHealth(_ context.Context, configId int64, health apiserver.ConnectionHealth) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	config := s.configs[configId]
+	config.Health = &health
+	s.configs[configId] = config
+	return nil
+}
+
+func (s *memStore) SetConfigCycleSummary(_ context.Context, configId int64, summary apiserver.CycleSummary) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summaries[configId] = summary
+	return nil
+}
+
+func (s *memStore) GetDevices(_ context.Context, configId int64) ([]apiserver.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var devices []apiserver.Device
+	for _, device := range s.devices {
+		if int64(device.ConfigId) == configId {
+			devices = append(devices, device)
+		}
+	}
+	return devices, nil
+}
+
+func (s *memStore) GetDevice(_ context.Context, configId int64, projectId string, deviceId string) (*apiserver.Device, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, device := range s.devices {
+		if int64(device.ConfigId) == configId && device.ProjectId == projectId && device.DeviceId == deviceId {
+			return &device, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) InsertDevice(_ context.Context, device apiserver.Device) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if device.State == "" {
+		device.State = conf.DeviceStateActive
+	}
+	s.devices = append(s.devices, device)
+	return nil
+}
+
+func (s *memStore) UpdateDevice(_ context.Context, device apiserver.Device) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, d := range s.devices {
+		if d.ConfigId == device.ConfigId && d.ProjectId == device.ProjectId && d.DeviceId == device.DeviceId {
+			s.devices[i] = device
+		}
+	}
+	return nil
+}
+
+func (s *memStore) DeleteDevice(_ context.Context, configId int64, projectId string, deviceId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, device := range s.devices {
+		if int64(device.ConfigId) == configId && device.ProjectId == projectId && device.DeviceId == deviceId {
+			s.devices = append(s.devices[:i], s.devices[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func newTestConfig(t *testing.T, fixtures mock.Fixtures, projIds ...string) (*mock.Server, apiserver.Configuration) {
+	t.Helper()
+	server := mock.NewServer("user", "secret", fixtures)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return server, apiserver.Configuration{
+		ConfigId:        1,
+		Username:        "user",
+		Password:        "secret",
+		Url:             httpServer.URL,
+		RequestTimeout:  5,
+		RefreshInterval: 60,
+		ProjIds:         &projIds,
+	}
+}
+
+func (s *memStore) GetAccessPoints(_ context.Context, configId int64) ([]apiserver.AccessPoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var accessPoints []apiserver.AccessPoint
+	for _, accessPoint := range s.accessPoints {
+		if int64(accessPoint.ConfigId) == configId {
+			accessPoints = append(accessPoints, accessPoint)
+		}
+	}
+	return accessPoints, nil
+}
+
+func (s *memStore) GetAccessPoint(_ context.Context, configId int64, projectId string, accessPointId string) (*apiserver.AccessPoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, accessPoint := range s.accessPoints {
+		if int64(accessPoint.ConfigId) == configId && accessPoint.ProjectId == projectId && accessPoint.AccessPointId == accessPointId {
+			return &accessPoint, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) GetAccessPointWithAssetId(_ context.Context, assetId int32) (*apiserver.AccessPoint, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, accessPoint := range s.accessPoints {
+		if accessPoint.AssetId == assetId {
+			return &accessPoint, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *memStore) InsertAccessPoint(_ context.Context, accessPoint apiserver.AccessPoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if accessPoint.State == "" {
+		accessPoint.State = conf.DeviceStateActive
+	}
+	s.accessPoints = append(s.accessPoints, accessPoint)
+	return nil
+}
+
+func (s *memStore) UpdateAccessPoint(_ context.Context, accessPoint apiserver.AccessPoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, a := range s.accessPoints {
+		if a.ConfigId == accessPoint.ConfigId && a.ProjectId == accessPoint.ProjectId && a.AccessPointId == accessPoint.AccessPointId {
+			s.accessPoints[i] = accessPoint
+		}
+	}
+	return nil
+}
+
+func (s *memStore) DeleteAccessPoint(_ context.Context, configId int64, projectId string, accessPointId string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var accessPoints []apiserver.AccessPoint
+	deletedAssets := make(map[int32]bool)
+	remaining := false
+	for _, accessPoint := range s.accessPoints {
+		if int64(accessPoint.ConfigId) != configId || accessPoint.AccessPointId != accessPointId {
+			accessPoints = append(accessPoints, accessPoint)
+		} else if accessPoint.ProjectId == projectId {
+			deletedAssets[accessPoint.AssetId] = true
+		} else {
+			accessPoints = append(accessPoints, accessPoint)
+			remaining = true
+		}
+	}
+	s.accessPoints = accessPoints
+	var commands []conf.Command
+	for _, command := range s.commands {
+		if command.ConfigId != configId || command.AccessPointId != accessPointId || !deletedAssets[command.AssetId] {
+			commands = append(commands, command)
+		}
+	}
+	s.commands = commands
+	if !remaining {
+		delete(s.doorStates, accessPointKey{configId: configId, accessPointId: accessPointId})
+	}
+	return nil
+}
+
+func (s *memStore) GetAlarmRules(_ context.Context, assetId int32) ([]conf.AlarmRule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var alarmRules []conf.AlarmRule
+	for _, alarmRule := range s.alarmRules {
+		if alarmRule.AssetId == assetId {
+			alarmRules = append(alarmRules, alarmRule)
+		}
+	}
+	return alarmRules, nil
+}
+
+func (s *memStore) UpsertAlarmRule(_ context.Context, alarmRule conf.AlarmRule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, a := range s.alarmRules {
+		if a.AssetId == alarmRule.AssetId && a.Attribute == alarmRule.Attribute {
+			s.alarmRules[i] = alarmRule
+			return nil
+		}
+	}
+	s.alarmRules = append(s.alarmRules, alarmRule)
+	return nil
+}
+
+func (s *memStore) DeleteAlarmRules(_ context.Context, assetId int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var alarmRules []conf.AlarmRule
+	for _, alarmRule := range s.alarmRules {
+		if alarmRule.AssetId != assetId {
+			alarmRules = append(alarmRules, alarmRule)
+		}
+	}
+	s.alarmRules = alarmRules
+	return nil
+}
+
+func (s *memStore) GetCommands(_ context.Context, states ...string) ([]conf.Command, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var commands []conf.Command
+	for _, command := range s.commands {
+		for _, state := range states {
+			if command.State == state {
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands, nil
+}
+
+func (s *memStore) InsertCommand(_ context.Context, command *conf.Command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	command.CommandId = int64(len(s.commands) + 1)
+	s.commands = append(s.commands, *command)
+	return nil
+}
+
+func (s *memStore) UpdateCommand(_ context.Context, command conf.Command) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.commands {
+		if s.commands[i].CommandId == command.CommandId {
+			s.commands[i] = command
+		}
+	}
+	return nil
+}
+
+func (s *memStore) GetDoorState(_ context.Context, configId int64, accessPointId string) (conf.DoorState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if doorState, ok := s.doorStates[accessPointKey{configId: configId, accessPointId: accessPointId}]; ok {
+		return doorState, nil
+	}
+	return conf.DoorState{ConfigId: configId, AccessPointId: accessPointId, State: conf.DoorStateClosed}, nil
+}
+
+func (s *memStore) GetDoorStates(_ context.Context, states ...string) ([]conf.DoorState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var doorStates []conf.DoorState
+	for _, doorState := range s.doorStates {
+		for _, state := range states {
+			if doorState.State == state {
+				doorStates = append(doorStates, doorState)
+			}
+		}
+	}
+	return doorStates, nil
+}
+
+func (s *memStore) UpdateDoorState(_ context.Context, configId int64, accessPointId string, update func(current conf.DoorState) (conf.DoorState, bool)) (conf.DoorState, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := accessPointKey{configId: configId, accessPointId: accessPointId}
+	current, ok := s.doorStates[key]
+	if !ok {
+		current = conf.DoorState{ConfigId: configId, AccessPointId: accessPointId, State: conf.DoorStateClosed}
+	}
+	doorState, changed := update(current)
+	if !changed {
+		return current, false, nil
+	}
+	s.doorStates[key] = doorState
+	return doorState, true, nil
+}
+
+// SetDoorState stores the door state directly for the setup of tests
+func (s *memStore) SetDoorState(_ context.Context, doorState conf.DoorState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.doorStates[accessPointKey{configId: doorState.ConfigId, accessPointId: doorState.AccessPointId}] = doorState
+	return nil
+}
+
+// Closes the due doors and waits until they are closed
+func closeDueDoors(ctx context.Context, st store, elionaFor func(apiserver.Configuration) eliona.Api, now time.Time) {
+	var closer doorCloser
+	closer.closeDue(ctx, st, elionaFor, now)
+	closer.wait()
+}
+
+// Handles the output synchronously with the resolve and handle functions of the dispatcher of the app
+func handleOutput(ctx context.Context, st store, elionaFor func(apiserver.Configuration) eliona.Api, output api.Data) {
+	dispatcher := newAccessPointDispatcher(st, elionaFor)
+	accessPoint, config, err := dispatcher.resolve(ctx, output)
+	if err != nil || accessPoint == nil || config == nil {
+		return
+	}
+	dispatcher.handle(ctx, *accessPoint, *config, output)
+}
+
+func inputData(el *eliona.Fake, assetId int32) map[string]interface{} {
+	return el.Data(assetId, api.SUBTYPE_INPUT)
+}
+
+func assetsOfType(el *eliona.Fake, assetType string) []api.Asset {
+	var assets []api.Asset
+	for _, asset := range el.Assets() {
+		if asset.AssetType == assetType {
+			assets = append(assets, asset)
+		}
+	}
+	return assets
+}
+
+func status(deviceId string, batteryLevel int64) *glutz.Result[glutz.DeviceStatus] {
+	return &glutz.Result[glutz.DeviceStatus]{Value: glutz.DeviceStatus{DeviceId: deviceId, BatteryLevel: batteryLevel}}
+}
+
+func location(accessPoint string) *glutz.Result[glutz.Location] {
+	return &glutz.Result[glutz.Location]{Value: glutz.Location{Building: "B", Room: "R", AccessPoint: accessPoint}}
+}
+
+func TestCollectDevices(t *testing.T) {
+	failed := errors.New("failed")
+	tests := []struct {
+		name        string
+		deviceList  []glutz.DeviceResult
+		statuses    map[string]*glutz.Result[glutz.DeviceStatus]
+		locations   map[string]*glutz.Result[glutz.Location]
+		wantBattery map[string]int64
+		wantAP      map[string]string
+		wantErrors  []string
+	}{
+		{
+			name: "all devices",
+			deviceList: []glutz.DeviceResult{
+				{Deviceid: "d1", AccessPointId: "ap1"},
+				{Deviceid: "d2", AccessPointId: "ap2"},
+			},
+			statuses:    map[string]*glutz.Result[glutz.DeviceStatus]{"d1": status("d1", 10), "d2": status("d2", 20)},
+			locations:   map[string]*glutz.Result[glutz.Location]{"ap1": location("Door 1"), "ap2": location("Door 2")},
+			wantBattery: map[string]int64{"d1": 10, "d2": 20},
+			wantAP:      map[string]string{"d1": "Door 1", "d2": "Door 2"},
+		},
+		{
+			name: "reordered device list",
+			deviceList: []glutz.DeviceResult{
+				{Deviceid: "d2", AccessPointId: "ap2"},
+				{Deviceid: "d1", AccessPointId: "ap1"},
+			},
+			statuses:    map[string]*glutz.Result[glutz.DeviceStatus]{"d1": status("d1", 10), "d2": status("d2", 20)},
+			locations:   map[string]*glutz.Result[glutz.Location]{"ap1": location("Door 1"), "ap2": location("Door 2")},
+			wantBattery: map[string]int64{"d1": 10, "d2": 20},
+			wantAP:      map[string]string{"d1": "Door 1", "d2": "Door 2"},
+		},
+		{
+			name: "missing status",
+			deviceList: []glutz.DeviceResult{
+				{Deviceid: "d1", AccessPointId: "ap1"},
+				{Deviceid: "d2", AccessPointId: "ap2"},
+				{Deviceid: "d3", AccessPointId: "ap3"},
+			},
+			statuses:    map[string]*glutz.Result[glutz.DeviceStatus]{"d1": status("d1", 10), "d3": status("d3", 30)},
+			locations:   map[string]*glutz.Result[glutz.Location]{"ap1": location("Door 1"), "ap2": location("Door 2"), "ap3": location("Door 3")},
+			wantBattery: map[string]int64{"d1": 10, "d3": 30},
+			wantAP:      map[string]string{"d1": "Door 1", "d3": "Door 3"},
+			wantErrors:  []string{"d2"},
+		},
+		{
+			name: "failed status and location",
+			deviceList: []glutz.DeviceResult{
+				{Deviceid: "d1", AccessPointId: "ap1"},
+				{Deviceid: "d2", AccessPointId: "ap2"},
+				{Deviceid: "d3", AccessPointId: "ap3"},
+			},
+			statuses: map[string]*glutz.Result[glutz.DeviceStatus]{
+				"d1": {Err: failed},
+				"d2": status("d2", 20),
+				"d3": status("d3", 30),
+			},
+			locations: map[string]*glutz.Result[glutz.Location]{
+				"ap1": location("Door 1"),
+				"ap2": location("Door 2"),
+				"ap3": {Err: failed},
+			},
+			wantBattery: map[string]int64{"d2": 20},
+			wantAP:      map[string]string{"d2": "Door 2"},
+			wantErrors:  []string{"d1", "d3"},
+		},
+		{
+			name: "shared access point",
+			deviceList: []glutz.DeviceResult{
+				{Deviceid: "d1", AccessPointId: "ap1"},
+				{Deviceid: "d2", AccessPointId: "ap1"},
+			},
+			statuses:    map[string]*glutz.Result[glutz.DeviceStatus]{"d1": status("d1", 10), "d2": status("d2", 20)},
+			locations:   map[string]*glutz.Result[glutz.Location]{"ap1": location("Door 1")},
+			wantBattery: map[string]int64{"d1": 10, "d2": 20},
+			wantAP:      map[string]string{"d1": "Door 1", "d2": "Door 1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			devices, deviceErrors := collectDevices(tt.deviceList, tt.statuses, tt.locations)
+			if len(devices) != len(tt.wantBattery) {
+				t.Fatalf("got %d devices, want %d", len(devices), len(tt.wantBattery))
+			}
+			for deviceId, batteryLevel := range tt.wantBattery {
+				device, ok := devices[deviceId]
+				if !ok {
+					t.Fatalf("device %s missing", deviceId)
+				}
+				if device.DeviceId != deviceId {
+					t.Errorf("device %s has id %s", deviceId, device.DeviceId)
+				}
+				if device.BatteryLevel != batteryLevel {
+					t.Errorf("device %s has battery level %d, want %d", deviceId, device.BatteryLevel, batteryLevel)
+				}
+				if device.AccessPoint != tt.wantAP[deviceId] {
+					t.Errorf("device %s has access point %s, want %s", deviceId, device.AccessPoint, tt.wantAP[deviceId])
+				}
+			}
+			if len(deviceErrors) != len(tt.wantErrors) {
+				t.Fatalf("got %d errors, want %d", len(deviceErrors), len(tt.wantErrors))
+			}
+			for _, deviceId := range tt.wantErrors {
+				if deviceErrors[deviceId] == nil {
+					t.Errorf("missing error for device %s", deviceId)
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceForMapping(t *testing.T) {
+	devices := glutz.DevicesDb{
+		"d1": {DeviceId: "d1", BatteryLevel: 10},
+		"d2": {DeviceId: "d2", BatteryLevel: 20},
+	}
+	tests := []struct {
+		name        string
+		mapping     apiserver.Device
+		wantBattery int64
+		wantErr     bool
+	}{
+		{name: "first device", mapping: apiserver.Device{AssetId: 1, DeviceId: "d1"}, wantBattery: 10},
+		{name: "second device", mapping: apiserver.Device{AssetId: 2, DeviceId: "d2"}, wantBattery: 20},
+		{name: "device missing in cycle", mapping: apiserver.Device{AssetId: 3, DeviceId: "d3"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			device, err := deviceForMapping(devices, &tt.mapping)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if device.DeviceId != tt.mapping.DeviceId || device.BatteryLevel != tt.wantBattery {
+				t.Errorf("asset %d got device %s with battery level %d, want device %s with battery level %d",
+					tt.mapping.AssetId, device.DeviceId, device.BatteryLevel, tt.mapping.DeviceId, tt.wantBattery)
+			}
+		})
+	}
+}
+
+func TestProcessDevices(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(3)
+	server, config := newTestConfig(t, fixtures, "1", "2")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+
+	processDevices(ctx, st, el, config)
+
+	if got, want := st.summaries[1], (apiserver.CycleSummary{Ok: 6, Created: 6}); got != want {
+		t.Errorf("got summary %+v, want %+v", got, want)
+	}
+	if len(assetsOfType(el, eliona.DeviceAssetType)) != 6 || len(st.devices) != 6 {
+		t.Fatalf("got %d assets and %d mappings, want 6", len(assetsOfType(el, eliona.DeviceAssetType)), len(st.devices))
+	}
+	for _, mapping := range st.devices {
+		var want mock.Device
+		for _, device := range fixtures.Devices {
+			if device.DeviceId == mapping.DeviceId {
+				want = device
+			}
+		}
+		data := inputData(el, mapping.AssetId)
+		if data["batteryLevel"] != float64(want.Status["batteryLevel"].(int)) {
+			t.Errorf("asset %d of device %s has battery level %v, want %v", mapping.AssetId, mapping.DeviceId, data["batteryLevel"], want.Status["batteryLevel"])
+		}
+		if data["rfWakeups"] != float64(want.Status["rfWakeups"].(int)) || data["batteryAlarm"] != float64(0) {
+			t.Errorf("asset %d of device %s has telemetry %v", mapping.AssetId, mapping.DeviceId, data)
+		}
+		if info := el.Data(mapping.AssetId, api.SUBTYPE_INFO); info["batteryPowered"] != float64(1) || info["lastUpdate"] != want.Status["lastUpdate"] {
+			t.Errorf("asset %d of device %s has info %v", mapping.AssetId, mapping.DeviceId, info)
+		}
+		if mapping.LocationId != want.AccessPointId {
+			t.Errorf("mapping of device %s has location %s, want %s", mapping.DeviceId, mapping.LocationId, want.AccessPointId)
+		}
+	}
+
+	// A second cycle reuses the mappings and skips assets deleted in Eliona
+	el.DeleteAsset(ctx, st.devices[0].AssetId)
+	server.SetDeviceStatus(fixtures.Devices[1].DeviceId, "batteryLevel", 5)
+	processDevices(ctx, st, el, config)
+
+	if got, want := st.summaries[1], (apiserver.CycleSummary{Ok: 5, Skipped: 1}); got != want {
+		t.Errorf("got summary %+v, want %+v", got, want)
+	}
+	for _, mapping := range st.devices {
+		if mapping.DeviceId == fixtures.Devices[1].DeviceId {
+			if battery := inputData(el, mapping.AssetId)["batteryLevel"]; battery != float64(5) {
+				t.Errorf("asset %d has battery level %v after update, want 5", mapping.AssetId, battery)
+			}
+		}
+	}
+}
+
+func TestProcessDevicesMigratesGlobalAssetIds(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	_, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := &migratingEliona{Fake: eliona.NewFake(), failing: true}
+
+	// An asset created by an earlier version of the app with the device id as global asset identifier
+	deviceId := fixtures.Devices[0].DeviceId
+	assetId, _ := el.UpsertAsset(ctx, api.Asset{ProjectId: "1", GlobalAssetIdentifier: deviceId, AssetType: eliona.DeviceAssetType})
+	st.InsertDevice(ctx, apiserver.Device{ConfigId: 1, ProjectId: "1", DeviceId: deviceId, AssetId: assetId, LocationId: "moved"})
+
+	// A failed migration is reported and no data is written, which would create a second asset
+	processDevices(ctx, st, el, config)
+
+	health := st.configs[1].Health
+	if health == nil || !strings.Contains(health.LastError, "migrating device assets") {
+		t.Fatalf("got health %+v, want migration error", health)
+	}
+	if got := len(assetsOfType(el.Fake, eliona.DeviceAssetType)); got != 1 {
+		t.Errorf("got %d device assets after failed migration, want 1", got)
+	}
+
+	// The migration is repeated in the next cycle and recorded
+	el.failing = false
+	processDevices(ctx, st, el, config)
+
+	assets := assetsOfType(el.Fake, eliona.DeviceAssetType)
+	if len(assets) != 1 || len(st.devices) != 1 {
+		t.Fatalf("got %d assets and %d mappings, want 1", len(assets), len(st.devices))
+	}
+	if want := "glutz:1:device:" + deviceId; assets[0].GlobalAssetIdentifier != want {
+		t.Errorf("got global asset id %s, want %s", assets[0].GlobalAssetIdentifier, want)
+	}
+	if st.devices[0].AssetId != assetId || st.devices[0].LocationId != fixtures.Devices[0].AccessPointId {
+		t.Errorf("got mapping %+v, want asset %d updated", st.devices[0], assetId)
+	}
+	if !st.migrated[1] {
+		t.Error("migration not recorded")
+	}
+
+	// Migrated configs are not migrated again
+	processDevices(ctx, st, el, config)
+	if el.migrated != 1 {
+		t.Errorf("got %d migrated assets, want 1", el.migrated)
+	}
+}
+
+// migratingEliona counts the assets migrated to a new global asset identifier and fails while failing is set
+type migratingEliona struct {
+	*eliona.Fake
+	failing  bool
+	migrated int
+}
+
+func (e *migratingEliona) SetGlobalAssetId(ctx context.Context, assetId int32, globalAssetId string) error {
+	if e.failing {
+		return errors.New("eliona unavailable")
+	}
+	e.migrated++
+	return e.Fake.SetGlobalAssetId(ctx, assetId, globalAssetId)
+}
+
+func TestProcessDevicesWithFailingDevice(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(4)
+	server, config := newTestConfig(t, fixtures, "1", "2")
+	config.BatchSize = 3
+	server.InjectError("eAccess.getModel", fixtures.Devices[2].DeviceId, 1, "device not reachable")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+
+	processDevices(ctx, st, el, config)
+
+	if got, want := st.summaries[1], (apiserver.CycleSummary{Ok: 6, Failed: 2, Created: 6}); got != want {
+		t.Errorf("got summary %+v, want %+v", got, want)
+	}
+	for _, mapping := range st.devices {
+		if mapping.DeviceId == fixtures.Devices[2].DeviceId {
+			t.Errorf("failed device %s was mapped to asset %d", mapping.DeviceId, mapping.AssetId)
+		}
+	}
+}
+
+func TestProcessDevicesUnreachable(t *testing.T) {
+	server, config := newTestConfig(t, mock.DemoFixtures(2), "1")
+	server.InjectError("eAccess.getModel", "", 1, "internal error")
+	st := newMemStore(config)
+
+	processDevices(context.Background(), st, eliona.NewFake(), config)
+
+	if _, ok := st.summaries[1]; ok {
+		t.Error("expected no summary if the device list cannot be read")
+	}
+}
+
+func TestConnectionHealth(t *testing.T) {
+	ctx := context.Background()
+	server, config := newTestConfig(t, mock.DemoFixtures(2), "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	refresh := func() apiserver.ConnectionHealth {
+		current, _ := st.GetConfig(ctx, 1)
+		processDevices(ctx, st, el, *current)
+		current, _ = st.GetConfig(ctx, 1)
+		if current.Health == nil {
+			t.Fatal("no connection health stored")
+		}
+		return *current.Health
+	}
+
+	health := refresh()
+	if health.State != conf.ConnectionStateConnected || health.LastSync == nil || health.ConsecutiveFailures != 0 || health.LastError != "" {
+		t.Errorf("got health %+v after successful cycle", health)
+	}
+	lastSync := *health.LastSync
+
+	server.InjectError("eAccess.getModel", "", 1, "internal error")
+	refresh()
+	health = refresh()
+	if health.State != conf.ConnectionStateDisconnected || health.ConsecutiveFailures != 2 || !strings.Contains(health.LastError, "internal error") {
+		t.Errorf("got health %+v after failed cycles", health)
+	}
+	if health.LastSync == nil || !health.LastSync.Equal(lastSync) {
+		t.Errorf("got last sync %v, want %v", health.LastSync, lastSync)
+	}
+
+	server.ClearErrors()
+	health = refresh()
+	if health.State != conf.ConnectionStateConnected || health.ConsecutiveFailures != 0 || !health.LastSync.After(lastSync) {
+		t.Errorf("got health %+v after recovery", health)
+	}
+	if health.LastError != "" {
+		t.Errorf("got last error %q after recovery, want none", health.LastError)
+	}
+
+	current, _ := st.GetConfig(ctx, 1)
+	current.Password = "wrong"
+	processDevices(ctx, st, el, *current)
+	current, _ = st.GetConfig(ctx, 1)
+	if current.Health.State != conf.ConnectionStateUnauthorized {
+		t.Errorf("got state %s, want %s", current.Health.State, conf.ConnectionStateUnauthorized)
+	}
+}
+
+func TestHandleOutput(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(2)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Building:   "Building",
+		Room:       "Room 1",
+		Name:       "Door 1",
+		Properties: map[string]string{glutz.OpenableDurationProperty: "1"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	if mapping == nil {
+		t.Fatal("access point not mapped")
+	}
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+
+	opens := server.Opens()
+	if len(opens) != 1 || opens[0].AccessPointId != "ap-1" || opens[0].Duration != "00:00:01" {
+		t.Fatalf("unexpected opens %+v", opens)
+	}
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(1) {
+		t.Errorf("got openable %v, want 1", openable)
+	}
+
+	// The door is open, a second request is recorded as ignored without reading the openable state from Eliona
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateOpen || doorState.CommandId != st.commands[0].CommandId {
+		t.Errorf("got door state %+v, want open by the command", doorState)
+	}
+	eliona.UpsertOpenData(ctx, el, 0, mapping.AssetId)
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+	if len(server.Opens()) != 1 {
+		t.Errorf("door opened again while open")
+	}
+
+	if len(st.commands) != 2 || st.commands[0].State != conf.CommandStateOpened || st.commands[0].CloseAt == nil {
+		t.Fatalf("got commands %+v, want an opened command", st.commands)
+	}
+	if ignored := st.commands[1]; ignored.State != conf.CommandStateIgnored || ignored.Error == "" || ignored.SentAt != nil {
+		t.Errorf("got command %+v, want ignored with reason", ignored)
+	}
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.CommandId != st.commands[0].CommandId {
+		t.Errorf("got door state %+v, want unchanged by the ignored command", doorState)
+	}
+
+	// The door stays open during the openable duration
+	closeDueDoors(ctx, st, elionaFor, time.Now())
+	if len(server.Opens()) != 1 || st.commands[0].State != conf.CommandStateOpened {
+		t.Errorf("door closed during openable duration")
+	}
+
+	// After the openable duration the door is closed again
+	closeDueDoors(ctx, st, elionaFor, time.Now().Add(2*time.Second))
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(0) {
+		t.Errorf("got openable %v, want 0", openable)
+	}
+	opens = server.Opens()
+	if len(opens) != 2 || opens[1].Duration != "00:00:00" {
+		t.Errorf("unexpected opens %+v", opens)
+	}
+	command := st.commands[0]
+	if command.State != conf.CommandStateClosed || command.SentAt == nil || command.OpenedAt == nil || command.ClosedAt == nil {
+		t.Errorf("got command %+v, want closed with timestamps", command)
+	}
+}
+
+func TestCloseDoorRetry(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Properties: map[string]string{glutz.OpenableDurationProperty: "1"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+
+	// Closing fails, the door may still be open and is closed again later
+	server.InjectError("eAccess.openAccessPoint", "ap-1", -32000, "device busy")
+	failedAt := time.Now().Add(2 * time.Second)
+	closeDueDoors(ctx, st, elionaFor, failedAt)
+	command := st.commands[0]
+	if command.State != conf.CommandStateOpened || command.ErrorCount != 1 || !strings.Contains(command.Error, "device busy") {
+		t.Fatalf("got command %+v, want opened with the error", command)
+	}
+	if command.CloseAt.Before(time.Now().Add(closeRetryDelay - time.Second)) {
+		t.Errorf("got retry at %v, want after %v", command.CloseAt, closeRetryDelay)
+	}
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateOpen {
+		t.Errorf("got door state %s, want %s", doorState.State, conf.DoorStateOpen)
+	}
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(2) {
+		t.Errorf("got openable %v, want 2", openable)
+	}
+
+	// The next try waits for the delay and closes the door
+	server.ClearErrors()
+	closeDueDoors(ctx, st, elionaFor, time.Now())
+	if st.commands[0].State != conf.CommandStateOpened {
+		t.Errorf("door closed again before the retry delay")
+	}
+	closeDueDoors(ctx, st, elionaFor, time.Now().Add(time.Minute))
+	if command := st.commands[0]; command.State != conf.CommandStateClosed {
+		t.Errorf("got command %+v, want closed", command)
+	}
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateClosed {
+		t.Errorf("got door state %s, want %s", doorState.State, conf.DoorStateClosed)
+	}
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(0) {
+		t.Errorf("got openable %v, want 0", openable)
+	}
+}
+
+func TestCloseRetryDelay(t *testing.T) {
+	for errorCount, want := range map[int32]time.Duration{1: closeRetryDelay, 2: 2 * closeRetryDelay, 3: 4 * closeRetryDelay, 100: closeRetryDelayMax} {
+		if got := closeRetryDelayAfter(errorCount); got != want {
+			t.Errorf("%d errors: got delay %v, want %v", errorCount, got, want)
+		}
+	}
+}
+
+func TestCloseDueDoorsPerConfig(t *testing.T) {
+	ctx := context.Background()
+	fastServer, fast := newTestConfig(t, mock.DemoFixtures(1), "1")
+	slowServer, slow := newTestConfig(t, mock.DemoFixtures(1), "1")
+	slow.ConfigId = 2
+	slowServer.SetLatency(300 * time.Millisecond)
+	st := newMemStore(fast, slow)
+	el := eliona.NewFake()
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	closeAt := time.Now()
+	for _, configId := range []int64{2, 1} {
+		st.InsertCommand(ctx, &conf.Command{ConfigId: configId, AssetId: 1, AccessPointId: "ap-1", State: conf.CommandStateOpened, Duration: 1, RequestedAt: closeAt, CloseAt: &closeAt})
+	}
+
+	// The door of the fast server is closed while the slow server is still closing its door
+	var closer doorCloser
+	closer.closeDue(ctx, st, elionaFor, time.Now())
+	deadline := time.Now().Add(200 * time.Millisecond)
+	for len(fastServer.Opens()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if len(fastServer.Opens()) != 1 {
+		t.Errorf("door of fast server not closed while the slow server was closing")
+	}
+
+	// The slow server isn't asked again while closing is still running
+	closer.closeDue(ctx, st, elionaFor, time.Now())
+	closer.wait()
+	if opens := slowServer.Opens(); len(opens) != 1 {
+		t.Errorf("got %d closes of the slow server, want 1", len(opens))
+	}
+	if closed, _ := st.GetCommands(ctx, conf.CommandStateClosed); len(closed) != 2 {
+		t.Errorf("got closed commands %+v, want 2", closed)
+	}
+}
+
+func TestHandleOutputFailed(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Properties: map[string]string{glutz.OpenableDurationProperty: "1"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+
+	server.InjectError("eAccess.openAccessPoint", "ap-1", 1, "door blocked")
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+
+	if len(st.commands) != 1 || st.commands[0].State != conf.CommandStateFailed || st.commands[0].FailedAt == nil {
+		t.Fatalf("got commands %+v, want one failed command", st.commands)
+	}
+	if !strings.Contains(st.commands[0].Error, "door blocked") {
+		t.Errorf("got error %q", st.commands[0].Error)
+	}
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(2) {
+		t.Errorf("got openable %v, want 2", openable)
+	}
+}
+
+func TestHandleOutputDefaultOpenableDuration(t *testing.T) {
+	// Doors without own openable duration have no property or the value 0 written by the app
+	for _, properties := range []map[string]string{{}, {glutz.OpenableDurationProperty: "0"}} {
+		ctx := context.Background()
+		fixtures := mock.DemoFixtures(1)
+		fixtures.AccessPoints["ap-1"] = mock.AccessPoint{Properties: properties}
+		server, config := newTestConfig(t, fixtures, "1")
+		config.DefaultOpenableDuration = 3
+		st := newMemStore(config)
+		el := eliona.NewFake()
+		processDevices(ctx, st, el, config)
+		mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+		elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+
+		handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+
+		opens := server.Opens()
+		if len(opens) != 1 || opens[0].Duration != "00:00:03" {
+			t.Errorf("properties %v: unexpected opens %+v", properties, opens)
+		}
+		if len(st.commands) != 1 || st.commands[0].State != conf.CommandStateOpened || st.commands[0].Duration != 3 {
+			t.Errorf("properties %v: got commands %+v, want one opened command", properties, st.commands)
+		}
+	}
+}
+
+func TestHandleOutputWithoutOpenableDuration(t *testing.T) {
+	// Neither the door nor the config define a positive openable duration or the property is invalid
+	for _, duration := range []string{"0", "soon"} {
+		t.Run(duration, func(t *testing.T) {
+			ctx := context.Background()
+			fixtures := mock.DemoFixtures(1)
+			fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+				Properties: map[string]string{glutz.OpenableDurationProperty: duration},
+			}
+			server, config := newTestConfig(t, fixtures, "1")
+			st := newMemStore(config)
+			el := eliona.NewFake()
+			processDevices(ctx, st, el, config)
+			mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+			elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+
+			// The request is recorded as failed instead of being dropped
+			handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+
+			if len(server.Opens()) != 0 {
+				t.Errorf("unexpected opens %+v", server.Opens())
+			}
+			if len(st.commands) != 1 || st.commands[0].State != conf.CommandStateFailed || !strings.Contains(st.commands[0].Error, "openable duration") {
+				t.Fatalf("got commands %+v, want one failed command", st.commands)
+			}
+			if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(2) {
+				t.Errorf("got openable %v, want 2", openable)
+			}
+			if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateClosed {
+				t.Errorf("got door state %s, want closed", doorState.State)
+			}
+		})
+	}
+}
+
+// failingCommandStore fails to store commands in the given state
+type failingCommandStore struct {
+	*memStore
+	state string
+}
+
+func (s failingCommandStore) UpdateCommand(ctx context.Context, command conf.Command) error {
+	if command.State == s.state {
+		return errors.New("database unavailable")
+	}
+	return s.memStore.UpdateCommand(ctx, command)
+}
+
+func TestHandleOutputOpenedNotStored(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Properties: map[string]string{glutz.OpenableDurationProperty: "1"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	mem := newMemStore(config)
+	st := failingCommandStore{memStore: mem, state: conf.CommandStateOpened}
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+
+	// The door state follows the opened door although the command was not stored
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateOpen {
+		t.Errorf("got door state %s, want open", doorState.State)
+	}
+
+	// The door is closed after the openable duration and the command stored again
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		commands, _ := st.GetCommands(ctx, conf.CommandStateClosed)
+		if len(commands) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("door not closed after the openable duration")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if opens := server.Opens(); len(opens) != 2 || opens[1].Duration != "00:00:00" {
+		t.Errorf("unexpected opens %+v", opens)
+	}
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateClosed {
+		t.Errorf("got door state %s, want closed", doorState.State)
+	}
+}
+
+// failingEliona fails to write data, e.g. if Eliona is not reachable
+type failingEliona struct {
+	*eliona.Fake
+}
+
+func (failingEliona) UpsertData(context.Context, api.Data) error {
+	return errors.New("eliona unavailable")
+}
+
+func TestHandleOutputElionaFailure(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Properties: map[string]string{glutz.OpenableDurationProperty: "1"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	fake := eliona.NewFake()
+	processDevices(ctx, st, fake, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	elionaFor := func(apiserver.Configuration) eliona.Api { return failingEliona{fake} }
+
+	// The door is opened and closed although the openable state can't be written
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+	closeDueDoors(ctx, st, elionaFor, time.Now().Add(2*time.Second))
+
+	if len(server.Opens()) != 2 {
+		t.Errorf("unexpected opens %+v", server.Opens())
+	}
+	command := st.commands[0]
+	if command.State != conf.CommandStateClosed || command.ErrorCount != 2 || !strings.Contains(command.Error, "eliona unavailable") {
+		t.Errorf("got command %+v, want closed with 2 errors", command)
+	}
+}
+
+func TestListenOutputs(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	httpServer := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for assetId := 1; assetId <= 3; assetId++ {
+			conn.WriteJSON(api.Data{AssetId: int32(assetId), Subtype: api.SUBTYPE_OUTPUT, Data: map[string]interface{}{"open": float64(1)}})
+		}
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	}))
+	defer httpServer.Close()
+	dial := func() (*websocket.Conn, error) {
+		conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(httpServer.URL, "http"), nil)
+		return conn, err
+	}
+
+	// A panic handling one output doesn't stop the listener
+	var handled []int32
+	err := listenOutputs(dial, func(output api.Data) {
+		handled = append(handled, output.AssetId)
+		if output.AssetId == 1 {
+			panic("handling failed")
+		}
+	})
+	if len(handled) != 3 {
+		t.Errorf("got handled outputs %v, want 3", handled)
+	}
+	if err == nil {
+		t.Error("expected error when the websocket is closed")
+	}
+
+	// The listener returns instead of blocking if the websocket can't be connected
+	err = listenOutputs(func() (*websocket.Conn, error) { return nil, errors.New("no connection") }, func(api.Data) {})
+	if err == nil || err.Error() != "no connection" {
+		t.Errorf("got error %v, want no connection", err)
+	}
+}
+
+func TestRecoverCommands(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	sentAt := time.Now().Add(-time.Minute)
+	st.InsertCommand(ctx, &conf.Command{ConfigId: 1, AssetId: 1, AccessPointId: "ap-1", State: conf.CommandStateRequested, Duration: 5, RequestedAt: sentAt})
+	st.InsertCommand(ctx, &conf.Command{ConfigId: 1, AssetId: 1, AccessPointId: "ap-1", State: conf.CommandStateSent, Duration: 5, RequestedAt: sentAt, SentAt: &sentAt})
+	st.InsertCommand(ctx, &conf.Command{ConfigId: 1, AssetId: 1, AccessPointId: "ap-1", Action: conf.CommandActionClose, State: conf.CommandStateSent, RequestedAt: sentAt, SentAt: &sentAt})
+
+	// The app restarts while a command was requested and others were sent
+	recoverCommands(ctx, st)
+	if st.commands[0].State != conf.CommandStateFailed {
+		t.Errorf("got state %s for requested command, want %s", st.commands[0].State, conf.CommandStateFailed)
+	}
+	if st.commands[1].State != conf.CommandStateOpened || !st.commands[1].CloseAt.Equal(sentAt.Add(5*time.Second)) {
+		t.Errorf("got command %+v for sent command, want opened until 5s after sending", st.commands[1])
+	}
+	if st.commands[2].State != conf.CommandStateFailed {
+		t.Errorf("got state %s for sent close command, want %s", st.commands[2].State, conf.CommandStateFailed)
+	}
+
+	// The door of the sent command is closed, the requested command is not sent
+	closeDueDoors(ctx, st, elionaFor, time.Now())
+	if opens := server.Opens(); len(opens) != 1 || opens[0].Duration != "00:00:00" {
+		t.Errorf("unexpected opens %+v", opens)
+	}
+	if st.commands[1].State != conf.CommandStateClosed {
+		t.Errorf("got state %s, want %s", st.commands[1].State, conf.CommandStateClosed)
+	}
+}
+
+func TestDoorStateOfficeMode(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Properties: map[string]string{glutz.OpenableDurationProperty: "1"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	deviceId := fixtures.Devices[0].DeviceId
+
+	// The device reports the office mode, the door is permanently open without a command of the app
+	server.SetDeviceStatus(deviceId, "operatingMode", glutz.OperatingModeOffice)
+	processDevices(ctx, st, el, config)
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStatePermanentlyOpen {
+		t.Errorf("got door state %s, want %s", doorState.State, conf.DoorStatePermanentlyOpen)
+	}
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(3) {
+		t.Errorf("got openable %v, want 3", openable)
+	}
+	// The office mode code is assumed, so open requests are sent anyway
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+	if len(server.Opens()) != 1 || st.commands[0].State != conf.CommandStateOpened {
+		t.Errorf("got opens %+v and commands %+v, want the door opened", server.Opens(), st.commands)
+	}
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStatePermanentlyOpen {
+		t.Errorf("got door state %s, want %s", doorState.State, conf.DoorStatePermanentlyOpen)
+	}
+
+	// After the office mode the door is closed and can be opened again
+	server.SetDeviceStatus(deviceId, "operatingMode", 0)
+	processDevices(ctx, st, el, config)
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateClosed {
+		t.Errorf("got door state %s, want %s", doorState.State, conf.DoorStateClosed)
+	}
+	if openable := inputData(el, mapping.AssetId)["openable"]; openable != float64(0) {
+		t.Errorf("got openable %v, want 0", openable)
+	}
+	handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": float64(1)}})
+	if len(server.Opens()) != 2 {
+		t.Errorf("got opens %+v, want 2", server.Opens())
+	}
+}
+
+func TestDoorCommands(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(1)
+	fixtures.AccessPoints["ap-1"] = mock.AccessPoint{
+		Properties: map[string]string{glutz.OpenableDurationProperty: "60"},
+	}
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	processDevices(ctx, st, el, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	write := func(open float64) {
+		handleOutput(ctx, st, elionaFor, api.Data{AssetId: mapping.AssetId, Data: map[string]interface{}{"open": open}})
+	}
+	check := func(doorState string, openable float64, opens int) {
+		t.Helper()
+		if current, _ := st.GetDoorState(ctx, 1, "ap-1"); current.State != doorState {
+			t.Errorf("got door state %s, want %s", current.State, doorState)
+		}
+		if got := inputData(el, mapping.AssetId)["openable"]; got != openable {
+			t.Errorf("got openable %v, want %v", got, openable)
+		}
+		if got := server.Opens(); len(got) != opens {
+			t.Errorf("got opens %+v, want %d", got, opens)
+		}
+	}
+
+	// A door opened for the openable duration is closed immediately
+	write(1)
+	check(conf.DoorStateOpen, 1, 1)
+	write(2)
+	check(conf.DoorStateClosed, 0, 2)
+	if opens := server.Opens(); opens[1].Duration != "00:00:00" {
+		t.Errorf("got close %+v, want duration 0", opens[1])
+	}
+	if st.commands[0].State != conf.CommandStateClosed || st.commands[1].Action != conf.CommandActionClose || st.commands[1].State != conf.CommandStateClosed {
+		t.Errorf("got commands %+v, want closed open and close commands", st.commands)
+	}
+
+	// The open command is not closed again after its openable duration
+	closeDueDoors(ctx, st, elionaFor, time.Now().Add(time.Hour))
+	check(conf.DoorStateClosed, 0, 2)
+
+	// A failed close is shown in openable, the door state is kept
+	write(1)
+	check(conf.DoorStateOpen, 1, 3)
+	server.InjectError("eAccess.openAccessPoint", "ap-1", -32000, "device busy")
+	write(2)
+	check(conf.DoorStateOpen, 2, 3)
+	if command := st.commands[len(st.commands)-1]; command.State != conf.CommandStateFailed || !strings.Contains(command.Error, "device busy") {
+		t.Errorf("got command %+v, want failed", command)
+	}
+
+	// Unknown values, also the former hold open and release, are ignored
+	for _, value := range []float64{3, 4, 5} {
+		write(value)
+	}
+	if len(st.commands) != 4 {
+		t.Errorf("got %d commands, want 4", len(st.commands))
+	}
+}
+
+func TestCommandDoorState(t *testing.T) {
+	tests := []struct {
+		name    string
+		current conf.DoorState
+		command conf.Command
+		want    string
+	}{
+		{name: "requested", current: conf.DoorState{State: conf.DoorStateClosed}, command: conf.Command{CommandId: 1, State: conf.CommandStateRequested}, want: conf.DoorStateOpening},
+		{name: "sent", current: conf.DoorState{State: conf.DoorStateOpening, CommandId: 1}, command: conf.Command{CommandId: 1, State: conf.CommandStateSent}, want: conf.DoorStateOpening},
+		{name: "opened", current: conf.DoorState{State: conf.DoorStateOpening, CommandId: 1}, command: conf.Command{CommandId: 1, State: conf.CommandStateOpened}, want: conf.DoorStateOpen},
+		{name: "closed", current: conf.DoorState{State: conf.DoorStateOpen, CommandId: 1}, command: conf.Command{CommandId: 1, State: conf.CommandStateClosed}, want: conf.DoorStateClosed},
+		{name: "failed", current: conf.DoorState{State: conf.DoorStateOpening, CommandId: 1}, command: conf.Command{CommandId: 1, State: conf.CommandStateFailed}, want: conf.DoorStateClosed},
+		{name: "closed by other command", current: conf.DoorState{State: conf.DoorStateOpen, CommandId: 2}, command: conf.Command{CommandId: 1, State: conf.CommandStateClosed}, want: conf.DoorStateOpen},
+		{name: "office mode", current: conf.DoorState{State: conf.DoorStatePermanentlyOpen}, command: conf.Command{CommandId: 1, State: conf.CommandStateClosed}, want: conf.DoorStatePermanentlyOpen},
+		{name: "ignored", current: conf.DoorState{State: conf.DoorStateOpen}, command: conf.Command{CommandId: 1, State: conf.CommandStateIgnored}, want: conf.DoorStateOpen},
+		{name: "closed immediately", current: conf.DoorState{State: conf.DoorStateOpen, CommandId: 2}, command: conf.Command{CommandId: 1, Action: conf.CommandActionClose, State: conf.CommandStateClosed}, want: conf.DoorStateClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandDoorState(tt.current, tt.command); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecoverDoorStates(t *testing.T) {
+	ctx := context.Background()
+	st := newMemStore()
+	requestedAt := time.Now()
+	st.InsertCommand(ctx, &conf.Command{ConfigId: 1, AccessPointId: "ap-1", State: conf.CommandStateOpened, Duration: 5, RequestedAt: requestedAt})
+	st.SetDoorState(ctx, conf.DoorState{ConfigId: 1, AccessPointId: "ap-1", State: conf.DoorStateOpen, CommandId: st.commands[0].CommandId})
+	// The app stopped after closing the door of ap-2 before its door state was stored
+	st.SetDoorState(ctx, conf.DoorState{ConfigId: 1, AccessPointId: "ap-2", State: conf.DoorStateOpen, CommandId: 42})
+	st.SetDoorState(ctx, conf.DoorState{ConfigId: 1, AccessPointId: "ap-3", State: conf.DoorStatePermanentlyOpen})
+
+	recoverCommands(ctx, st)
+	want := map[string]string{"ap-1": conf.DoorStateOpen, "ap-2": conf.DoorStateClosed, "ap-3": conf.DoorStatePermanentlyOpen}
+	for accessPointId, state := range want {
+		if doorState, _ := st.GetDoorState(ctx, 1, accessPointId); doorState.State != state {
+			t.Errorf("got door state %s of %s, want %s", doorState.State, accessPointId, state)
+		}
+	}
+}
+
+// openingStore opens the door of ap-1 by a new command while the active commands are read, like a
+// worker handling an output at the same time
+type openingStore struct {
+	*memStore
+}
+
+func (s openingStore) GetCommands(ctx context.Context, states ...string) ([]conf.Command, error) {
+	commands, err := s.memStore.GetCommands(ctx, states...)
+	s.SetDoorState(ctx, conf.DoorState{ConfigId: 1, AccessPointId: "ap-1", State: conf.DoorStateOpening, CommandId: 43})
+	return commands, err
+}
+
+func TestRecoverDoorStatesChanged(t *testing.T) {
+	ctx := context.Background()
+	st := openingStore{newMemStore()}
+	st.SetDoorState(ctx, conf.DoorState{ConfigId: 1, AccessPointId: "ap-1", State: conf.DoorStateOpen, CommandId: 42})
+
+	// The door is not closed as its state changed since it was read
+	recoverDoorStates(ctx, st)
+	if doorState, _ := st.GetDoorState(ctx, 1, "ap-1"); doorState.State != conf.DoorStateOpening || doorState.CommandId != 43 {
+		t.Errorf("got door state %+v, want opening by command 43", doorState)
+	}
+}
+
+func TestHandleOutputIgnored(t *testing.T) {
+	ctx := context.Background()
+	server, config := newTestConfig(t, mock.DemoFixtures(1), "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	assetId := st.accessPoints[0].AssetId
+
+	tests := []struct {
+		name   string
+		output api.Data
+	}{
+		{name: "unknown asset", output: api.Data{AssetId: assetId + 100, Data: map[string]interface{}{"open": float64(1)}}},
+		{name: "device asset", output: api.Data{AssetId: st.devices[0].AssetId, Data: map[string]interface{}{"open": float64(1)}}},
+		{name: "open is zero", output: api.Data{AssetId: assetId, Data: map[string]interface{}{"open": float64(0)}}},
+		{name: "no open attribute", output: api.Data{AssetId: assetId, Data: map[string]interface{}{}}},
+		// The cycle defined the openable duration of all access points as 0
+		{name: "no openable duration", output: api.Data{AssetId: assetId, Data: map[string]interface{}{"open": float64(1)}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handleOutput(ctx, st, elionaFor, tt.output)
+			if opens := server.Opens(); len(opens) != 0 {
+				t.Errorf("unexpected opens %+v", opens)
+			}
+		})
+	}
+}
+
+func TestOrphanPolicy(t *testing.T) {
+	tests := []struct {
+		policy       string
+		wantAssets   int
+		wantMappings int
+		wantOrphaned interface{}
+	}{
+		{policy: "", wantAssets: 3, wantMappings: 3, wantOrphaned: float64(0)},
+		{policy: "keep", wantAssets: 3, wantMappings: 3, wantOrphaned: float64(0)},
+		{policy: "mark", wantAssets: 3, wantMappings: 3, wantOrphaned: float64(1)},
+		{policy: "delete", wantAssets: 2, wantMappings: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			ctx := context.Background()
+			fixtures := mock.DemoFixtures(3)
+			server, config := newTestConfig(t, fixtures, "1")
+			config.OrphanPolicy = tt.policy
+			st := newMemStore(config)
+			el := eliona.NewFake()
+			processDevices(ctx, st, el, config)
+			orphan, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[1].DeviceId)
+
+			// The device disappears from the Glutz server
+			server.RemoveDevice(fixtures.Devices[1].DeviceId)
+			processDevices(ctx, st, el, config)
+
+			if got := st.summaries[1]; got.Orphaned != 1 || got.Ok != 2 || got.Failed != 0 {
+				t.Errorf("got summary %+v", got)
+			}
+			if len(assetsOfType(el, eliona.DeviceAssetType)) != tt.wantAssets || len(st.devices) != tt.wantMappings {
+				t.Errorf("got %d assets and %d mappings, want %d and %d", len(assetsOfType(el, eliona.DeviceAssetType)), len(st.devices), tt.wantAssets, tt.wantMappings)
+			}
+			info := el.Data(orphan.AssetId, api.SUBTYPE_INFO)
+			if info["orphaned"] != tt.wantOrphaned {
+				t.Errorf("got orphaned %v, want %v", info["orphaned"], tt.wantOrphaned)
+			}
+		})
+	}
+}
+
+func TestOrphanPolicyWithoutDevices(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(2)
+	server, config := newTestConfig(t, fixtures, "1")
+	config.OrphanPolicy = conf.OrphanPolicyDelete
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+	assets := len(el.Assets())
+
+	// The controller reports no devices while it restarts, nothing is deleted
+	for _, device := range fixtures.Devices {
+		server.RemoveDevice(device.DeviceId)
+	}
+	processDevices(ctx, st, el, config)
+
+	if got := len(el.Assets()); got != assets || len(st.devices) != 2 || len(st.accessPoints) != 2 {
+		t.Errorf("got %d assets, %d device and %d access point mappings, want %d, 2 and 2", got, len(st.devices), len(st.accessPoints), assets)
+	}
+	if got := st.summaries[1]; got.Orphaned != 0 {
+		t.Errorf("got summary %+v, want no orphans", got)
+	}
+}
+
+func TestDeleteOrphanedAccessPoint(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(2)
+	server, config := newTestConfig(t, fixtures, "1")
+	config.OrphanPolicy = conf.OrphanPolicyDelete
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	elionaFor := func(apiserver.Configuration) eliona.Api { return el }
+	processDevices(ctx, st, el, config)
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-2")
+	openedAt := time.Now().Add(-time.Minute)
+	st.InsertCommand(ctx, &conf.Command{ConfigId: 1, AssetId: mapping.AssetId, AccessPointId: "ap-2", State: conf.CommandStateOpened, Duration: 5, RequestedAt: openedAt, OpenedAt: &openedAt, CloseAt: &openedAt})
+	st.SetDoorState(ctx, conf.DoorState{ConfigId: 1, AccessPointId: "ap-2", State: conf.DoorStateOpen, CommandId: st.commands[0].CommandId})
+
+	// The access point disappears while its door is open
+	server.RemoveDevice(fixtures.Devices[1].DeviceId)
+	processDevices(ctx, st, el, config)
+	if len(st.commands) != 0 || len(st.doorStates) != 0 {
+		t.Errorf("got commands %+v and door states %+v of the deleted access point", st.commands, st.doorStates)
+	}
+	closeDueDoors(ctx, st, elionaFor, time.Now())
+	if opens := server.Opens(); len(opens) != 0 {
+		t.Errorf("unexpected opens %+v for the deleted access point", opens)
+	}
+}
+
+func TestMissingAssetPolicy(t *testing.T) {
+	tests := []struct {
+		policy      string
+		wantSummary apiserver.CycleSummary
+		wantState   string
+		wantAssets  int
+	}{
+		{policy: "", wantSummary: apiserver.CycleSummary{Ok: 1, Skipped: 1}, wantState: conf.DeviceStateUserDeleted, wantAssets: 1},
+		{policy: "ignore", wantSummary: apiserver.CycleSummary{Ok: 1, Skipped: 1}, wantState: conf.DeviceStateUserDeleted, wantAssets: 1},
+		{policy: "recreate", wantSummary: apiserver.CycleSummary{Ok: 2, Created: 1}, wantState: conf.DeviceStateActive, wantAssets: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			ctx := context.Background()
+			fixtures := mock.DemoFixtures(2)
+			_, config := newTestConfig(t, fixtures, "1")
+			config.MissingAssetPolicy = tt.policy
+			st := newMemStore(config)
+			el := eliona.NewFake()
+			processDevices(ctx, st, el, config)
+			deleted, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[0].DeviceId)
+			el.DeleteAsset(ctx, deleted.AssetId)
+
+			for i := 0; i < 2; i++ {
+				processDevices(ctx, st, el, config)
+				if got := st.summaries[1]; got != tt.wantSummary {
+					t.Errorf("cycle %d: got summary %+v, want %+v", i, got, tt.wantSummary)
+				}
+				tt.wantSummary.Created = 0
+			}
+			mapping, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[0].DeviceId)
+			if mapping.State != tt.wantState {
+				t.Errorf("got state %s, want %s", mapping.State, tt.wantState)
+			}
+			if len(assetsOfType(el, eliona.DeviceAssetType)) != tt.wantAssets {
+				t.Errorf("got %d assets, want %d", len(assetsOfType(el, eliona.DeviceAssetType)), tt.wantAssets)
+			}
+			if tt.wantState == conf.DeviceStateActive {
+				if mapping.AssetId == deleted.AssetId {
+					t.Errorf("mapping still references deleted asset %d", deleted.AssetId)
+				}
+				if inputData(el, mapping.AssetId) == nil {
+					t.Errorf("no data for recreated asset %d", mapping.AssetId)
+				}
+			}
+		})
+	}
+}
+
+func TestSyncAssetNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		sync     *bool
+		wantName string
+	}{
+		{name: "default", wantName: "Door 1a, Room 7, Building"},
+		{name: "enabled", sync: common.Ptr(true), wantName: "Door 1a, Room 7, Building"},
+		{name: "disabled", sync: common.Ptr(false), wantName: "Door 1, Room 1, Building"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			fixtures := mock.DemoFixtures(2)
+			server, config := newTestConfig(t, fixtures, "1")
+			config.SyncAssetNames = tt.sync
+			st := newMemStore(config)
+			el := eliona.NewFake()
+			processDevices(ctx, st, el, config)
+
+			server.SetLocation("ap-1", "Building", "Room 7", "Door 1a")
+			processDevices(ctx, st, el, config)
+
+			if got := st.summaries[1]; got.Ok != 2 || got.Failed != 0 {
+				t.Errorf("got summary %+v", got)
+			}
+			mapping, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[0].DeviceId)
+			for _, asset := range el.Assets() {
+				if *asset.Id.Get() == mapping.AssetId && asset.Name.Get() != nil && *asset.Name.Get() != tt.wantName {
+					t.Errorf("got asset name %s, want %s", *asset.Name.Get(), tt.wantName)
+				}
+			}
+			accessPoint, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+			if info := el.Data(accessPoint.AssetId, api.SUBTYPE_INFO); info["room"] != "Room 7" {
+				t.Errorf("got room %v, want Room 7", info["room"])
+			}
+		})
+	}
+}
+
+func TestLocationAssets(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(12)
+	server, config := newTestConfig(t, fixtures, "1", "2")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+
+	// One building and two rooms per project
+	if got := len(assetsOfType(el, eliona.BuildingAssetType)); got != 2 {
+		t.Errorf("got %d building assets, want 2", got)
+	}
+	rooms := assetsOfType(el, eliona.RoomAssetType)
+	if len(rooms) != 4 {
+		t.Fatalf("got %d room assets, want 4", len(rooms))
+	}
+	roomIds := make(map[string]int32)
+	for _, room := range rooms {
+		if room.ParentLocationalAssetId.Get() == nil || *room.ParentLocationalAssetId.Get() == 0 {
+			t.Errorf("room %s has no building", room.GlobalAssetIdentifier)
+		}
+		if room.ProjectId == "1" {
+			roomIds[*room.Name.Get()] = *room.Id.Get()
+		}
+	}
+	mapping, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	if mapping.ParentAssetId != roomIds["Room 1"] {
+		t.Errorf("got parent asset %d, want Room 1 %d", mapping.ParentAssetId, roomIds["Room 1"])
+	}
+
+	// The access point moves to the other room
+	server.SetLocation("ap-1", "Building", "Room 2", "Door 1")
+	processDevices(ctx, st, el, config)
+
+	if got := len(assetsOfType(el, eliona.RoomAssetType)); got != 4 {
+		t.Errorf("got %d room assets, want 4", got)
+	}
+	mapping, _ = st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	if mapping.ParentAssetId != roomIds["Room 2"] {
+		t.Errorf("got parent asset %d, want Room 2 %d", mapping.ParentAssetId, roomIds["Room 2"])
+	}
+	for _, asset := range el.Assets() {
+		if *asset.Id.Get() == mapping.AssetId && *asset.ParentLocationalAssetId.Get() != roomIds["Room 2"] {
+			t.Errorf("got asset parent %d, want %d", *asset.ParentLocationalAssetId.Get(), roomIds["Room 2"])
+		}
+	}
+
+	// Renaming the building and a room in Glutz creates new location assets and removes the old ones
+	for accessPointId, accessPoint := range fixtures.AccessPoints {
+		room := accessPoint.Room
+		if room == "Room 1" || accessPointId == "ap-1" {
+			room = "Lobby"
+		}
+		server.SetLocation(accessPointId, "Main building", room, accessPoint.Name)
+	}
+	processDevices(ctx, st, el, config)
+
+	buildings := assetsOfType(el, eliona.BuildingAssetType)
+	if len(buildings) != 2 || *buildings[0].Name.Get() != "Main building" {
+		t.Errorf("got buildings %+v, want only Main building per project", buildings)
+	}
+	names := make(map[string]int)
+	for _, room := range assetsOfType(el, eliona.RoomAssetType) {
+		names[*room.Name.Get()]++
+	}
+	if !reflect.DeepEqual(names, map[string]int{"Lobby": 2, "Room 2": 2}) {
+		t.Errorf("got rooms %v, want Lobby and Room 2 per project", names)
+	}
+}
+
+func TestLocationAssetIdsDontCollide(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(2)
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+
+	// A location asset of an earlier version of the app without prefix is removed as nothing is placed under it
+	oldId, _ := el.UpsertAsset(ctx, api.Asset{ProjectId: "1", GlobalAssetIdentifier: "glutz:1:Building", AssetType: eliona.BuildingAssetType})
+
+	// The room of ap-1 has the global asset id of the access point ap-2 without prefixes
+	server.SetLocation("ap-1", "access-point", "ap-2", "Door 1")
+	processDevices(ctx, st, el, config)
+
+	if exists, _ := el.ExistAsset(ctx, oldId); exists {
+		t.Errorf("old location asset %d not removed", oldId)
+	}
+
+	if got := len(assetsOfType(el, eliona.AccessPointAssetType)); got != 2 {
+		t.Errorf("got %d access point assets, want 2", got)
+	}
+	if got := len(assetsOfType(el, eliona.RoomAssetType)); got != 2 {
+		t.Errorf("got %d room assets, want 2", got)
+	}
+	for _, asset := range el.Assets() {
+		if strings.Contains(asset.GlobalAssetIdentifier, "glutz:1:access-point:ap-2") && asset.AssetType != eliona.AccessPointAssetType {
+			t.Errorf("asset %s has type %s", asset.GlobalAssetIdentifier, asset.AssetType)
+		}
+	}
+}
+
+func TestAccessPointAssets(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(3)
+	// Reader and escutcheon of the same door
+	fixtures.Devices[1].AccessPointId = "ap-1"
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+
+	if got := st.summaries[1]; got.Ok != 3 || got.Created != 3 {
+		t.Errorf("got summary %+v", got)
+	}
+	if got := len(assetsOfType(el, eliona.AccessPointAssetType)); got != 2 || len(st.accessPoints) != 2 {
+		t.Fatalf("got %d access point assets and %d mappings, want 2", got, len(st.accessPoints))
+	}
+	accessPoint, _ := st.GetAccessPoint(ctx, 1, "1", "ap-1")
+	for _, device := range fixtures.Devices[:2] {
+		mapping, _ := st.GetDevice(ctx, 1, "1", device.DeviceId)
+		if mapping.ParentAssetId != accessPoint.AssetId {
+			t.Errorf("device %s: got parent asset %d, want access point %d", device.DeviceId, mapping.ParentAssetId, accessPoint.AssetId)
+		}
+		if info := el.Data(mapping.AssetId, api.SUBTYPE_INFO); info["room"] != nil {
+			t.Errorf("device %s: got location info %v", device.DeviceId, info)
+		}
+	}
+	if info := el.Data(accessPoint.AssetId, api.SUBTYPE_INFO); info["accessPoint"] != "Door 1" || info["room"] != "Room 1" {
+		t.Errorf("got access point info %v", info)
+	}
+
+	// The door of the third device is removed, its access point asset is deleted with the device asset
+	config.OrphanPolicy = conf.OrphanPolicyDelete
+	server.RemoveDevice(fixtures.Devices[2].DeviceId)
+	processDevices(ctx, st, el, config)
+
+	if got := len(assetsOfType(el, eliona.AccessPointAssetType)); got != 1 || len(st.accessPoints) != 1 {
+		t.Errorf("got %d access point assets and %d mappings, want 1", got, len(st.accessPoints))
+	}
+}
+
+func TestAlarmRules(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(2)
+	server, config := newTestConfig(t, fixtures, "1")
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	processDevices(ctx, st, el, config)
+
+	if got := len(el.AlarmRules()); got != 10 || len(st.alarmRules) != 10 {
+		t.Fatalf("got %d alarm rules and %d stored, want 10", got, len(st.alarmRules))
+	}
+	mapping, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[0].DeviceId)
+	batteryLow := func() api.AlarmRule {
+		for _, rule := range el.AlarmRules() {
+			if rule.AssetId == mapping.AssetId && rule.Attribute == "batteryLevel" {
+				return rule
+			}
+		}
+		t.Fatal("no battery alarm rule")
+		return api.AlarmRule{}
+	}
+	if low := batteryLow().Low.Get(); low == nil || *low != 20 {
+		t.Errorf("got battery low %v, want 20", low)
+	}
+
+	// Changed thresholds update the existing rules
+	config.BatteryLowThreshold = common.Ptr[int32](30)
+	server.SetDeviceStatus(fixtures.Devices[0].DeviceId, "communicationErrors", 4)
+	processDevices(ctx, st, el, config)
+
+	if got := len(el.AlarmRules()); got != 10 {
+		t.Errorf("got %d alarm rules, want 10", got)
+	}
+	if low := batteryLow().Low.Get(); low == nil || *low != 30 {
+		t.Errorf("got battery low %v, want 30", low)
+	}
+	if increase := inputData(el, mapping.AssetId)["communicationErrorsIncrease"]; increase != float64(3) {
+		t.Errorf("got communication errors increase %v, want 3", increase)
+	}
+
+	// Without new errors the increase is back to 0
+	processDevices(ctx, st, el, config)
+	if increase := inputData(el, mapping.AssetId)["communicationErrorsIncrease"]; increase != float64(0) {
+		t.Errorf("got communication errors increase %v, want 0", increase)
+	}
+}
+
+func TestStaleDevices(t *testing.T) {
+	ctx := context.Background()
+	fixtures := mock.DemoFixtures(3)
+	server, config := newTestConfig(t, fixtures, "1")
+	config.StaleThreshold = 600
+	st := newMemStore(config)
+	el := eliona.NewFake()
+	server.SetDeviceStatus(fixtures.Devices[1].DeviceId, "lastUpdate", time.Now().Add(-time.Hour).UTC().Format(time.RFC3339))
+	server.SetDeviceStatus(fixtures.Devices[2].DeviceId, "lastUpdate", "unknown")
+	processDevices(ctx, st, el, config)
+
+	for i, want := range []float64{1, 0, 1} {
+		mapping, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[i].DeviceId)
+		if online := inputData(el, mapping.AssetId)["online"]; online != want {
+			t.Errorf("device %d: got online %v, want %v", i, online, want)
+		}
+	}
+
+	mapping, _ := st.GetDevice(ctx, 1, "1", fixtures.Devices[1].DeviceId)
+	for _, rule := range el.AlarmRules() {
+		if rule.AssetId == mapping.AssetId && rule.Attribute == "online" {
+			if equal := rule.Equal.Get(); equal == nil || *equal != 0 {
+				t.Errorf("got offline alarm on %v, want 0", equal)
+			}
+			return
+		}
+	}
+	t.Error("no offline alarm rule")
+}
+
+func TestIsOnlineTimeZone(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	// Reported without zone 30 minutes ago by a server in Zurich (UTC+1)
+	device := glutz.DeviceDb{DeviceId: "d1", LastUpdate: "2024-03-01 13:00:00"}
+	config := apiserver.Configuration{ConfigId: 1, StaleThreshold: 3600, TimeZone: common.Ptr("Europe/Zurich")}
+	if !isOnline(config, device, now) {
+		t.Error("device in Zurich offline, want online")
+	}
+	// Read in Tokyo (UTC+9) the same timestamp is 8.5 hours old
+	config.TimeZone = common.Ptr("Asia/Tokyo")
+	if isOnline(config, device, now) {
+		t.Error("device in Tokyo online, want offline")
+	}
+
+	// An unreadable last update is remembered to warn only once, until it can be read again
+	device.LastUpdate = "unknown"
+	if !isOnline(config, device, now) || !unreadableLastUpdates.devices["1/d1"] {
+		t.Error("unreadable last update not ignored")
+	}
+	device.LastUpdate = "2024-03-01 07:00:00"
+	if isOnline(config, device, now); unreadableLastUpdates.devices["1/d1"] {
+		t.Error("readable last update still marked as unreadable")
+	}
+}
+
+// The value maps of the access point asset type have to list the values of open and openable used by the app
+func TestAccessPointValueMaps(t *testing.T) {
+	payload, err := os.ReadFile("eliona/asset-type-glutz_access_point.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var assetType struct {
+		Attributes []struct {
+			Name string `json:"name"`
+			Map  []struct {
+				Value float64 `json:"value"`
+			} `json:"map"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(payload, &assetType); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]map[float64]bool{
+		"open":     {0: true},
+		"openable": {},
+	}
+	for value := range outputActions {
+		want["open"][value] = true
+	}
+	for _, value := range []int32{openableClosed, openableOpen, openableFailed, openablePermanentlyOpen} {
+		want["openable"][float64(value)] = true
+	}
+	for _, attribute := range assetType.Attributes {
+		values, ok := want[attribute.Name]
+		if !ok {
+			continue
+		}
+		mapped := make(map[float64]bool)
+		for _, mapping := range attribute.Map {
+			mapped[mapping.Value] = true
+		}
+		if !reflect.DeepEqual(mapped, values) {
+			t.Errorf("%s: got mapped values %v, want %v", attribute.Name, mapped, values)
+		}
+		delete(want, attribute.Name)
+	}
+	for name := range want {
+		t.Errorf("attribute %s not found", name)
+	}
+}
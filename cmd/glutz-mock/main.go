@@ -0,0 +1,56 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Command glutz-mock runs a fake Glutz eAccess server for demos and local development of the app.
+package main
+
+import (
+	"flag"
+	"glutz/glutz/mock"
+	"net/http"
+	"time"
+
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "address to listen on")
+	username := flag.String("username", "admin", "username for basic authentication")
+	password := flag.String("password", "admin", "password for basic authentication")
+	fixturesFile := flag.String("fixtures", "", "JSON file with devices and access points")
+	devices := flag.Int("devices", 10, "number of generated demo devices if no fixtures file is given")
+	latency := flag.Duration("latency", 0, "delay for each request")
+	flag.Parse()
+
+	fixtures := mock.DemoFixtures(*devices)
+	if *fixturesFile != "" {
+		var err error
+		fixtures, err = mock.LoadFixtures(*fixturesFile)
+		if err != nil {
+			log.Fatal("mock", "Error loading fixtures: %v", err)
+		}
+	}
+	server := mock.NewServer(*username, *password, fixtures)
+	server.SetLatency(*latency)
+	server.OnOpen(func(open mock.Open) {
+		log.Info("mock", "Opened access point %s for %s", open.AccessPointId, open.Duration)
+	})
+
+	log.Info("mock", "Glutz mock server with %d devices listening on %s", len(fixtures.Devices), *addr)
+	httpServer := &http.Server{Addr: *addr, Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatal("mock", "Error running server: %v", err)
+	}
+}
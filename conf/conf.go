@@ -17,8 +17,10 @@ package conf
 
 import (
 	"context"
+	"fmt"
 	"glutz/apiserver"
 	dbglutz "glutz/db/glutz"
+	"time"
 
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/db"
@@ -27,6 +29,89 @@ import (
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 )
 
+const defaultRequestTimeout = 120 * time.Second
+
+const defaultBatchSize = 50
+
+const defaultBatteryLowThreshold = 20
+
+const defaultStaleThreshold = time.Hour
+
+const defaultMaxConcurrentCommands = 4
+
+// Policies for mappings of devices no longer reported by the Glutz server
+const (
+	OrphanPolicyKeep   = "keep"
+	OrphanPolicyMark   = "mark"
+	OrphanPolicyDelete = "delete"
+)
+
+// Policies for mappings whose asset was deleted in Eliona
+const (
+	MissingAssetPolicyIgnore   = "ignore"
+	MissingAssetPolicyRecreate = "recreate"
+)
+
+// States of a device mapping
+const (
+	DeviceStateActive      = "active"
+	DeviceStateUserDeleted = "user_deleted"
+)
+
+// States of a door open command. A command is requested when open is written in Eliona, sent when the open
+// request is posted to the Glutz server, opened when the server confirmed it and closed when the door was
+// closed again after the openable duration. It fails if the door could not be opened or closed. A request
+// to open a door which the app is already opening is ignored, its error names the reason.
+const (
+	CommandStateRequested = "requested"
+	CommandStateSent      = "sent"
+	CommandStateOpened    = "opened"
+	CommandStateClosed    = "closed"
+	CommandStateFailed    = "failed"
+	CommandStateIgnored   = "ignored"
+)
+
+// Actions of a command written to the open attribute in Eliona: open the door for the openable duration or
+// close it immediately.
+const (
+	CommandActionOpen  = "open"
+	CommandActionClose = "close"
+)
+
+// States of the door of an access point. A door is opening while an open command is requested or sent, open
+// after the Glutz server confirmed the command and closed again after the openable duration. It is
+// permanently open while a device of the access point reports the office mode.
+const (
+	DoorStateClosed          = "closed"
+	DoorStateOpening         = "opening"
+	DoorStateOpen            = "open"
+	DoorStatePermanentlyOpen = "permanently_open"
+)
+
+// States of the connection to the Glutz server of a configuration
+const (
+	ConnectionStateConnected    = "connected"
+	ConnectionStateDisconnected = "disconnected"
+	ConnectionStateUnauthorized = "unauthorized"
+	// The stored password cannot be decrypted, e.g. because the key has changed
+	ConnectionStateInvalidPassword = "invalid_password"
+)
+
+// Columns only written by the app. They are kept if a configuration is updated through the API.
+var configStateColumns = []string{
+	dbglutz.ConfigColumns.CycleOk,
+	dbglutz.ConfigColumns.CycleFailed,
+	dbglutz.ConfigColumns.CycleCreated,
+	dbglutz.ConfigColumns.CycleSkipped,
+	dbglutz.ConfigColumns.CycleOrphaned,
+	dbglutz.ConfigColumns.ConnectionState,
+	dbglutz.ConfigColumns.LastSyncAt,
+	dbglutz.ConfigColumns.LastError,
+	dbglutz.ConfigColumns.ConsecutiveFailures,
+	dbglutz.ConfigColumns.LastCycleDuration,
+	dbglutz.ConfigColumns.DeviceAssetsMigrated,
+}
+
 func GetDevices(ctx context.Context, configId int64) ([]apiserver.Device, error) {
 	var mods []qm.QueryMod
 	if configId > 0 {
@@ -117,8 +202,11 @@ func GetConfigs(ctx context.Context) ([]apiserver.Configuration, error) {
 }
 
 func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserver.Configuration, error) {
-	dbConfig := dbConfigFromApiConfig(&config)
-	err := dbConfig.Insert(ctx, db.Database("glutz"), boil.Blacklist(dbglutz.ConfigColumns.ConfigID))
+	dbConfig, err := dbConfigFromApiConfig(&config)
+	if err != nil {
+		return apiserver.Configuration{}, err
+	}
+	err = dbConfig.Insert(ctx, db.Database("glutz"), boil.Blacklist(defaultConfigColumns(config)...))
 	if err != nil {
 		return apiserver.Configuration{}, err
 	}
@@ -126,28 +214,383 @@ func InsertConfig(ctx context.Context, config apiserver.Configuration) (apiserve
 	return config, err
 }
 
+// UpsertConfigById updates or creates the configuration with the given id. Omitted fields and an empty
+// password keep the stored values of an existing configuration.
 func UpsertConfigById(ctx context.Context, configId int64, config apiserver.Configuration) (apiserver.Configuration, error) {
-	dbConfig := dbConfigFromApiConfig(&config)
+	dbConfig, err := dbConfigFromApiConfig(&config)
+	if err != nil {
+		return apiserver.Configuration{}, err
+	}
 	dbConfig.ConfigID = configId
-	err := dbConfig.Upsert(ctx, db.Database("glutz"), true,
+	err = dbConfig.Upsert(ctx, db.Database("glutz"), true,
 		[]string{dbglutz.ConfigColumns.ConfigID},
-		boil.Blacklist(dbglutz.ConfigColumns.ConfigID),
+		boil.Blacklist(keptConfigColumns(config)...),
 		boil.Infer(),
 	)
 	config.ConfigId = dbConfig.ConfigID
 	return config, err
 }
 
-func InsertSpace(ctx context.Context, configId int64, projectId string, deviceId string, assetId int32, locationId string) error {
-	var dbDevice dbglutz.Device
-	dbDevice.ConfigID = configId
-	dbDevice.ProjectID = projectId
-	dbDevice.DeviceID = deviceId
-	dbDevice.AssetID = assetId
-	dbDevice.LocationID = locationId
+// EncryptPasswords encrypts all passwords still stored in plaintext, e.g. by an earlier version of the app,
+// with the key from the environment. It returns the number of encrypted passwords.
+func EncryptPasswords(ctx context.Context) (int, error) {
+	key, err := passwordKey()
+	if err != nil {
+		return 0, err
+	}
+	if key == nil {
+		return 0, ErrNoPasswordKey
+	}
+	dbConfigs, err := dbglutz.Configs(
+		dbglutz.ConfigWhere.Password.NEQ(""),
+		qm.Where(dbglutz.ConfigColumns.Password+" not like ?", encryptedPasswordPrefix+"%"),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return 0, err
+	}
+	for _, dbConfig := range dbConfigs {
+		if dbConfig.Password, err = encryptPassword(dbConfig.Password); err != nil {
+			return 0, err
+		}
+		if _, err := dbConfig.Update(ctx, db.Database("glutz"), boil.Whitelist(dbglutz.ConfigColumns.Password)); err != nil {
+			return 0, fmt.Errorf("updating config %d: %w", dbConfig.ConfigID, err)
+		}
+	}
+	return len(dbConfigs), nil
+}
+
+func DeleteDevice(ctx context.Context, configId int64, projectId string, deviceId string) (int64, error) {
+	return dbglutz.Devices(
+		dbglutz.DeviceWhere.ConfigID.EQ(configId),
+		dbglutz.DeviceWhere.ProjectID.EQ(projectId),
+		dbglutz.DeviceWhere.DeviceID.EQ(deviceId),
+	).DeleteAll(ctx, db.Database("glutz"))
+}
+
+// InsertDevice stores a new device mapping.
+func InsertDevice(ctx context.Context, device apiserver.Device) error {
+	dbDevice := dbDeviceFromApiDevice(&device)
 	return dbDevice.Insert(ctx, db.Database("glutz"), boil.Infer())
 }
 
+// UpdateDevice updates the device mapping identified by config, project and device id.
+func UpdateDevice(ctx context.Context, device apiserver.Device) (int64, error) {
+	dbDevice := dbDeviceFromApiDevice(&device)
+	return dbDevice.Update(ctx, db.Database("glutz"), boil.Infer())
+}
+
+func GetAccessPoints(ctx context.Context, configId int64) ([]apiserver.AccessPoint, error) {
+	var mods []qm.QueryMod
+	if configId > 0 {
+		mods = append(mods, dbglutz.AccessPointWhere.ConfigID.EQ(configId))
+	}
+	dbAccessPoints, err := dbglutz.AccessPoints(mods...).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	var apiAccessPoints []apiserver.AccessPoint
+	for _, dbAccessPoint := range dbAccessPoints {
+		apiAccessPoints = append(apiAccessPoints, *apiAccessPointFromDbAccessPoint(dbAccessPoint))
+	}
+	return apiAccessPoints, nil
+}
+
+func GetAccessPoint(ctx context.Context, configId int64, projectId string, accessPointId string) (*apiserver.AccessPoint, error) {
+	dbAccessPoints, err := dbglutz.AccessPoints(
+		dbglutz.AccessPointWhere.ConfigID.EQ(configId),
+		dbglutz.AccessPointWhere.ProjectID.EQ(projectId),
+		dbglutz.AccessPointWhere.AccessPointID.EQ(accessPointId),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	if len(dbAccessPoints) != 1 {
+		return nil, nil
+	}
+	return apiAccessPointFromDbAccessPoint(dbAccessPoints[0]), nil
+}
+
+func GetAccessPointWithAssetId(ctx context.Context, assetId int32) (*apiserver.AccessPoint, error) {
+	dbAccessPoints, err := dbglutz.AccessPoints(
+		dbglutz.AccessPointWhere.AssetID.EQ(assetId),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	if len(dbAccessPoints) != 1 {
+		return nil, nil
+	}
+	return apiAccessPointFromDbAccessPoint(dbAccessPoints[0]), nil
+}
+
+// InsertAccessPoint stores a new access point mapping.
+func InsertAccessPoint(ctx context.Context, accessPoint apiserver.AccessPoint) error {
+	dbAccessPoint := dbAccessPointFromApiAccessPoint(&accessPoint)
+	return dbAccessPoint.Insert(ctx, db.Database("glutz"), boil.Infer())
+}
+
+// UpdateAccessPoint updates the access point mapping identified by config, project and access point id.
+func UpdateAccessPoint(ctx context.Context, accessPoint apiserver.AccessPoint) (int64, error) {
+	dbAccessPoint := dbAccessPointFromApiAccessPoint(&accessPoint)
+	return dbAccessPoint.Update(ctx, db.Database("glutz"), boil.Infer())
+}
+
+// DeleteAccessPoint removes the mapping of an access point together with the commands of its asset in one
+// transaction. The door state is removed as well if no other project maps the access point.
+func DeleteAccessPoint(ctx context.Context, configId int64, projectId string, accessPointId string) (int64, error) {
+	tx, err := db.Database("glutz").BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	mapping := []qm.QueryMod{
+		dbglutz.AccessPointWhere.ConfigID.EQ(configId),
+		dbglutz.AccessPointWhere.ProjectID.EQ(projectId),
+		dbglutz.AccessPointWhere.AccessPointID.EQ(accessPointId),
+	}
+	dbAccessPoints, err := dbglutz.AccessPoints(mapping...).All(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	for _, dbAccessPoint := range dbAccessPoints {
+		_, err := dbglutz.Commands(
+			dbglutz.CommandWhere.ConfigID.EQ(configId),
+			dbglutz.CommandWhere.AccessPointID.EQ(accessPointId),
+			dbglutz.CommandWhere.AssetID.EQ(dbAccessPoint.AssetID),
+		).DeleteAll(ctx, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	count, err := dbglutz.AccessPoints(mapping...).DeleteAll(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	remaining, err := dbglutz.AccessPoints(
+		dbglutz.AccessPointWhere.ConfigID.EQ(configId),
+		dbglutz.AccessPointWhere.AccessPointID.EQ(accessPointId),
+	).Count(ctx, tx)
+	if err != nil {
+		return 0, err
+	}
+	if remaining == 0 {
+		_, err := dbglutz.DoorStates(
+			dbglutz.DoorStateWhere.ConfigID.EQ(configId),
+			dbglutz.DoorStateWhere.AccessPointID.EQ(accessPointId),
+		).DeleteAll(ctx, tx)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return count, tx.Commit()
+}
+
+// AlarmRule is an alarm rule the app created in Eliona for an attribute of an asset.
+type AlarmRule struct {
+	AssetId     int32
+	Attribute   string
+	AlarmRuleId int32
+	Threshold   int32
+}
+
+// GetAlarmRules returns the alarm rules created for the given asset.
+func GetAlarmRules(ctx context.Context, assetId int32) ([]AlarmRule, error) {
+	dbAlarmRules, err := dbglutz.AlarmRules(
+		dbglutz.AlarmRuleWhere.AssetID.EQ(assetId),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	var alarmRules []AlarmRule
+	for _, dbAlarmRule := range dbAlarmRules {
+		alarmRules = append(alarmRules, AlarmRule{
+			AssetId:     dbAlarmRule.AssetID,
+			Attribute:   dbAlarmRule.Attribute,
+			AlarmRuleId: dbAlarmRule.AlarmRuleID,
+			Threshold:   dbAlarmRule.Threshold,
+		})
+	}
+	return alarmRules, nil
+}
+
+// UpsertAlarmRule stores the alarm rule created for an attribute of an asset.
+func UpsertAlarmRule(ctx context.Context, alarmRule AlarmRule) error {
+	dbAlarmRule := dbglutz.AlarmRule{
+		AssetID:     alarmRule.AssetId,
+		Attribute:   alarmRule.Attribute,
+		AlarmRuleID: alarmRule.AlarmRuleId,
+		Threshold:   alarmRule.Threshold,
+	}
+	return dbAlarmRule.Upsert(ctx, db.Database("glutz"), true, []string{dbglutz.AlarmRuleColumns.AssetID, dbglutz.AlarmRuleColumns.Attribute}, boil.Infer(), boil.Infer())
+}
+
+// DeleteAlarmRules removes the alarm rules stored for the given asset.
+func DeleteAlarmRules(ctx context.Context, assetId int32) (int64, error) {
+	return dbglutz.AlarmRules(
+		dbglutz.AlarmRuleWhere.AssetID.EQ(assetId),
+	).DeleteAll(ctx, db.Database("glutz"))
+}
+
+// Command is a request to open or close the door of an access point. Open commands open the door for the
+// given duration, CloseAt is the time the door is closed again or, after closing failed, the time of the next
+// try. The time of each state is recorded. ErrorCount counts all errors while handling the command, Error is
+// the last one.
+type Command struct {
+	CommandId     int64
+	ConfigId      int64
+	AssetId       int32
+	AccessPointId string
+	Action        string
+	State         string
+	Duration      int32
+	Error         string
+	ErrorCount    int32
+	RequestedAt   time.Time
+	SentAt        *time.Time
+	OpenedAt      *time.Time
+	CloseAt       *time.Time
+	ClosedAt      *time.Time
+	FailedAt      *time.Time
+}
+
+// GetCommands returns the commands in one of the given states, oldest first.
+func GetCommands(ctx context.Context, states ...string) ([]Command, error) {
+	dbCommands, err := dbglutz.Commands(
+		dbglutz.CommandWhere.State.IN(states),
+		qm.OrderBy(dbglutz.CommandColumns.CommandID),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	var commands []Command
+	for _, dbCommand := range dbCommands {
+		commands = append(commands, commandFromDbCommand(dbCommand))
+	}
+	return commands, nil
+}
+
+// InsertCommand stores a new command and returns its id. Commands without action open the door.
+func InsertCommand(ctx context.Context, command Command) (int64, error) {
+	if command.Action == "" {
+		command.Action = CommandActionOpen
+	}
+	dbCommand := dbCommandFromCommand(command)
+	err := dbCommand.Insert(ctx, db.Database("glutz"), boil.Blacklist(dbglutz.CommandColumns.CommandID))
+	if err != nil {
+		return 0, err
+	}
+	return dbCommand.CommandID, nil
+}
+
+// UpdateCommand stores the state of a command.
+func UpdateCommand(ctx context.Context, command Command) (int64, error) {
+	dbCommand := dbCommandFromCommand(command)
+	return dbCommand.Update(ctx, db.Database("glutz"), boil.Infer())
+}
+
+func commandFromDbCommand(dbCommand *dbglutz.Command) Command {
+	return Command{
+		CommandId:     dbCommand.CommandID,
+		ConfigId:      dbCommand.ConfigID,
+		AssetId:       dbCommand.AssetID,
+		AccessPointId: dbCommand.AccessPointID,
+		Action:        dbCommand.Action,
+		State:         dbCommand.State,
+		Duration:      dbCommand.Duration,
+		Error:         dbCommand.Error.String,
+		ErrorCount:    dbCommand.ErrorCount,
+		RequestedAt:   dbCommand.RequestedAt,
+		SentAt:        dbCommand.SentAt.Ptr(),
+		OpenedAt:      dbCommand.OpenedAt.Ptr(),
+		CloseAt:       dbCommand.CloseAt.Ptr(),
+		ClosedAt:      dbCommand.ClosedAt.Ptr(),
+		FailedAt:      dbCommand.FailedAt.Ptr(),
+	}
+}
+
+func dbCommandFromCommand(command Command) dbglutz.Command {
+	return dbglutz.Command{
+		CommandID:     command.CommandId,
+		ConfigID:      command.ConfigId,
+		AssetID:       command.AssetId,
+		AccessPointID: command.AccessPointId,
+		Action:        command.Action,
+		State:         command.State,
+		Duration:      command.Duration,
+		Error:         null.NewString(command.Error, command.Error != ""),
+		ErrorCount:    command.ErrorCount,
+		RequestedAt:   command.RequestedAt,
+		SentAt:        null.TimeFromPtr(command.SentAt),
+		OpenedAt:      null.TimeFromPtr(command.OpenedAt),
+		CloseAt:       null.TimeFromPtr(command.CloseAt),
+		ClosedAt:      null.TimeFromPtr(command.ClosedAt),
+		FailedAt:      null.TimeFromPtr(command.FailedAt),
+	}
+}
+
+// DoorState is the state of the door of an access point. CommandId is the command which changed the state
+// last, if any.
+type DoorState struct {
+	ConfigId      int64
+	AccessPointId string
+	State         string
+	CommandId     int64
+	ChangedAt     time.Time
+}
+
+// GetDoorState returns the state of the door of the given access point. It returns nil if no state is stored.
+func GetDoorState(ctx context.Context, configId int64, accessPointId string) (*DoorState, error) {
+	dbDoorStates, err := dbglutz.DoorStates(
+		dbglutz.DoorStateWhere.ConfigID.EQ(configId),
+		dbglutz.DoorStateWhere.AccessPointID.EQ(accessPointId),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	if len(dbDoorStates) != 1 {
+		return nil, nil
+	}
+	doorState := doorStateFromDbDoorState(dbDoorStates[0])
+	return &doorState, nil
+}
+
+// GetDoorStates returns the door states in one of the given states.
+func GetDoorStates(ctx context.Context, states ...string) ([]DoorState, error) {
+	dbDoorStates, err := dbglutz.DoorStates(
+		dbglutz.DoorStateWhere.State.IN(states),
+	).All(ctx, db.Database("glutz"))
+	if err != nil {
+		return nil, err
+	}
+	var doorStates []DoorState
+	for _, dbDoorState := range dbDoorStates {
+		doorStates = append(doorStates, doorStateFromDbDoorState(dbDoorState))
+	}
+	return doorStates, nil
+}
+
+// UpsertDoorState stores the state of the door of an access point.
+func UpsertDoorState(ctx context.Context, doorState DoorState) error {
+	dbDoorState := dbglutz.DoorState{
+		ConfigID:      doorState.ConfigId,
+		AccessPointID: doorState.AccessPointId,
+		State:         doorState.State,
+		CommandID:     null.NewInt64(doorState.CommandId, doorState.CommandId != 0),
+		ChangedAt:     doorState.ChangedAt,
+	}
+	return dbDoorState.Upsert(ctx, db.Database("glutz"), true, []string{dbglutz.DoorStateColumns.ConfigID, dbglutz.DoorStateColumns.AccessPointID}, boil.Infer(), boil.Infer())
+}
+
+func doorStateFromDbDoorState(dbDoorState *dbglutz.DoorState) DoorState {
+	return DoorState{
+		ConfigId:      dbDoorState.ConfigID,
+		AccessPointId: dbDoorState.AccessPointID,
+		State:         dbDoorState.State,
+		CommandId:     dbDoorState.CommandID.Int64,
+		ChangedAt:     dbDoorState.ChangedAt,
+	}
+}
+
 func SetConfigActiveState(configID int64, state bool) (int64, error) {
 	return dbglutz.Configs(
 		dbglutz.ConfigWhere.ConfigID.EQ(null.Int64FromPtr(&configID).Int64),
@@ -164,14 +607,153 @@ func SetConfigInitialisedState(configID int64, state bool) (int64, error) {
 	})
 }
 
+// SetConfigCycleSummary stores the outcome of the last refresh cycle of the configuration.
+func SetConfigCycleSummary(ctx context.Context, configID int64, summary apiserver.CycleSummary) (int64, error) {
+	return dbglutz.Configs(
+		dbglutz.ConfigWhere.ConfigID.EQ(configID),
+	).UpdateAll(ctx, db.Database("glutz"), dbglutz.M{
+		dbglutz.ConfigColumns.CycleOk:       summary.Ok,
+		dbglutz.ConfigColumns.CycleFailed:   summary.Failed,
+		dbglutz.ConfigColumns.CycleCreated:  summary.Created,
+		dbglutz.ConfigColumns.CycleSkipped:  summary.Skipped,
+		dbglutz.ConfigColumns.CycleOrphaned: summary.Orphaned,
+	})
+}
+
+// SetConfigHealth stores the state of the connection to the Glutz server of the configuration.
+func SetConfigHealth(ctx context.Context, configID int64, health apiserver.ConnectionHealth) (int64, error) {
+	return dbglutz.Configs(
+		dbglutz.ConfigWhere.ConfigID.EQ(configID),
+	).UpdateAll(ctx, db.Database("glutz"), dbglutz.M{
+		dbglutz.ConfigColumns.ConnectionState:     health.State,
+		dbglutz.ConfigColumns.LastSyncAt:          null.TimeFromPtr(health.LastSync),
+		dbglutz.ConfigColumns.LastError:           health.LastError,
+		dbglutz.ConfigColumns.ConsecutiveFailures: health.ConsecutiveFailures,
+		dbglutz.ConfigColumns.LastCycleDuration:   health.LastCycleDuration,
+	})
+}
+
+// AreDeviceAssetsMigrated returns whether the device assets of the configuration were migrated to the global
+// asset identifiers including the configuration id.
+func AreDeviceAssetsMigrated(ctx context.Context, configID int64) (bool, error) {
+	dbConfig, err := dbglutz.Configs(
+		dbglutz.ConfigWhere.ConfigID.EQ(configID),
+	).One(ctx, db.Database("glutz"))
+	if err != nil {
+		return false, err
+	}
+	return dbConfig.DeviceAssetsMigrated.Bool, nil
+}
+
+// SetDeviceAssetsMigrated records that the device assets of the configuration were migrated.
+func SetDeviceAssetsMigrated(ctx context.Context, configID int64) (int64, error) {
+	return dbglutz.Configs(
+		dbglutz.ConfigWhere.ConfigID.EQ(configID),
+	).UpdateAll(ctx, db.Database("glutz"), dbglutz.M{
+		dbglutz.ConfigColumns.DeviceAssetsMigrated: true,
+	})
+}
+
 func IsConfigActive(config apiserver.Configuration) bool {
 	return config.Active == nil || *config.Active
 }
 
+// PasswordError returns an error if the stored password of the configuration cannot be decrypted
+func PasswordError(config apiserver.Configuration) error {
+	if config.Health == nil || config.Health.State != ConnectionStateInvalidPassword {
+		return nil
+	}
+	return fmt.Errorf("config %d: %s", config.ConfigId, config.Health.LastError)
+}
+
 func IsConfigEnabled(config apiserver.Configuration) bool {
 	return config.Enable == nil || *config.Enable
 }
 
+func IsAssetNameSyncEnabled(config apiserver.Configuration) bool {
+	return config.SyncAssetNames == nil || *config.SyncAssetNames
+}
+
+// RequestTimeout returns the configured timeout for a single request to the Glutz server or to Eliona.
+func RequestTimeout(config apiserver.Configuration) time.Duration {
+	if config.RequestTimeout <= 0 {
+		return defaultRequestTimeout
+	}
+	return time.Duration(config.RequestTimeout) * time.Second
+}
+
+// BatchSize returns the maximum number of calls sent to the Glutz server in a single batch request.
+func BatchSize(config apiserver.Configuration) int {
+	if config.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return int(config.BatchSize)
+}
+
+// OrphanPolicy returns how mappings of devices no longer reported by the Glutz server are handled.
+func OrphanPolicy(config apiserver.Configuration) string {
+	switch config.OrphanPolicy {
+	case OrphanPolicyMark, OrphanPolicyDelete:
+		return config.OrphanPolicy
+	}
+	return OrphanPolicyKeep
+}
+
+// MissingAssetPolicy returns how mappings are handled whose asset was deleted in Eliona.
+func MissingAssetPolicy(config apiserver.Configuration) string {
+	if config.MissingAssetPolicy == MissingAssetPolicyRecreate {
+		return MissingAssetPolicyRecreate
+	}
+	return MissingAssetPolicyIgnore
+}
+
+// BatteryLowThreshold returns the battery level in percent below which the low battery alarm is triggered.
+func BatteryLowThreshold(config apiserver.Configuration) int32 {
+	if config.BatteryLowThreshold == nil || *config.BatteryLowThreshold <= 0 {
+		return defaultBatteryLowThreshold
+	}
+	return *config.BatteryLowThreshold
+}
+
+// CommunicationErrorsThreshold returns the number of new communication errors per cycle above which the
+// communication alarm is triggered.
+func CommunicationErrorsThreshold(config apiserver.Configuration) int32 {
+	if config.CommunicationErrorsThreshold == nil || *config.CommunicationErrorsThreshold < 0 {
+		return 0
+	}
+	return *config.CommunicationErrorsThreshold
+}
+
+// StaleThreshold returns the time since the last update of a device after which the device is offline.
+func StaleThreshold(config apiserver.Configuration) time.Duration {
+	if config.StaleThreshold <= 0 {
+		return defaultStaleThreshold
+	}
+	return time.Duration(config.StaleThreshold) * time.Second
+}
+
+// MaxConcurrentCommands returns the maximum number of door open commands handled at the same time for the
+// Glutz server of the configuration.
+func MaxConcurrentCommands(config apiserver.Configuration) int {
+	if config.MaxConcurrentCommands <= 0 {
+		return defaultMaxConcurrentCommands
+	}
+	return int(config.MaxConcurrentCommands)
+}
+
+// TimeZone returns the time zone of the Glutz server for timestamps reported without zone. Without configured
+// time zone, it is the time zone of the app, set by TZ.
+func TimeZone(config apiserver.Configuration) *time.Location {
+	if config.TimeZone == nil || *config.TimeZone == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(*config.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
 func SetAllConfigsInactive(ctx context.Context) (int64, error) {
 	return dbglutz.Configs().UpdateAllG(ctx, dbglutz.M{
 		dbglutz.ConfigColumns.Active: false,
@@ -185,15 +767,111 @@ func apiDevicesFromDbDevices(dbDevices *dbglutz.Device) *apiserver.Device {
 	apiDevices.ConfigId = int32(dbDevices.ConfigID)
 	apiDevices.ProjectId = dbDevices.ProjectID
 	apiDevices.AssetId = dbDevices.AssetID
+	apiDevices.DeviceId = dbDevices.DeviceID
+	apiDevices.State = dbDevices.State
+	apiDevices.AssetName = dbDevices.AssetName.String
 	apiDevices.LocationId = dbDevices.LocationID
+	apiDevices.ParentAssetId = dbDevices.ParentAssetID.Int32
+	apiDevices.CommunicationErrors = dbDevices.CommunicationErrors.Ptr()
 	return &apiDevices
 }
 
+func dbDeviceFromApiDevice(apiDevice *apiserver.Device) *dbglutz.Device {
+	var dbDevice dbglutz.Device
+	dbDevice.ConfigID = int64(apiDevice.ConfigId)
+	dbDevice.ProjectID = apiDevice.ProjectId
+	dbDevice.DeviceID = apiDevice.DeviceId
+	dbDevice.AssetID = apiDevice.AssetId
+	dbDevice.LocationID = apiDevice.LocationId
+	dbDevice.State = apiDevice.State
+	if dbDevice.State == "" {
+		dbDevice.State = DeviceStateActive
+	}
+	dbDevice.AssetName = null.StringFrom(apiDevice.AssetName)
+	if apiDevice.ParentAssetId != 0 {
+		dbDevice.ParentAssetID = null.Int32From(apiDevice.ParentAssetId)
+	}
+	dbDevice.CommunicationErrors = null.Int64FromPtr(apiDevice.CommunicationErrors)
+	return &dbDevice
+}
+
+func apiAccessPointFromDbAccessPoint(dbAccessPoint *dbglutz.AccessPoint) *apiserver.AccessPoint {
+	var apiAccessPoint apiserver.AccessPoint
+	apiAccessPoint.ConfigId = int32(dbAccessPoint.ConfigID)
+	apiAccessPoint.ProjectId = dbAccessPoint.ProjectID
+	apiAccessPoint.AssetId = dbAccessPoint.AssetID
+	apiAccessPoint.AccessPointId = dbAccessPoint.AccessPointID
+	apiAccessPoint.State = dbAccessPoint.State
+	apiAccessPoint.AssetName = dbAccessPoint.AssetName.String
+	apiAccessPoint.ParentAssetId = dbAccessPoint.ParentAssetID.Int32
+	return &apiAccessPoint
+}
+
+func dbAccessPointFromApiAccessPoint(apiAccessPoint *apiserver.AccessPoint) *dbglutz.AccessPoint {
+	var dbAccessPoint dbglutz.AccessPoint
+	dbAccessPoint.ConfigID = int64(apiAccessPoint.ConfigId)
+	dbAccessPoint.ProjectID = apiAccessPoint.ProjectId
+	dbAccessPoint.AccessPointID = apiAccessPoint.AccessPointId
+	dbAccessPoint.AssetID = apiAccessPoint.AssetId
+	dbAccessPoint.State = apiAccessPoint.State
+	if dbAccessPoint.State == "" {
+		dbAccessPoint.State = DeviceStateActive
+	}
+	dbAccessPoint.AssetName = null.StringFrom(apiAccessPoint.AssetName)
+	if apiAccessPoint.ParentAssetId != 0 {
+		dbAccessPoint.ParentAssetID = null.Int32From(apiAccessPoint.ParentAssetId)
+	}
+	return &dbAccessPoint
+}
+
+// Columns not updated by UpsertConfigById for the given configuration
+func keptConfigColumns(config apiserver.Configuration) []string {
+	keep := append([]string{dbglutz.ConfigColumns.ConfigID}, configStateColumns...)
+	if config.Password == "" {
+		keep = append(keep, dbglutz.ConfigColumns.Password)
+	}
+	return append(keep, omittedConfigColumns(config)...)
+}
+
+// Columns left to their database defaults by InsertConfig for the given configuration
+func defaultConfigColumns(config apiserver.Configuration) []string {
+	return append([]string{dbglutz.ConfigColumns.ConfigID}, omittedConfigColumns(config)...)
+}
+
+// Columns of the optional fields omitted in the given configuration. Fields without null value in the API
+// count as omitted if they are zero.
+func omittedConfigColumns(config apiserver.Configuration) []string {
+	var omitted []string
+	omit := func(isOmitted bool, column string) {
+		if isOmitted {
+			omitted = append(omitted, column)
+		}
+	}
+	omit(config.Active == nil, dbglutz.ConfigColumns.Active)
+	omit(config.Enable == nil, dbglutz.ConfigColumns.Enable)
+	omit(config.DefaultOpenableDuration == 0, dbglutz.ConfigColumns.DefaultOpenableDuration)
+	omit(config.Initialized == nil, dbglutz.ConfigColumns.Initialized)
+	omit(config.ProjIds == nil, dbglutz.ConfigColumns.ProjectIds)
+	omit(config.BatchSize == 0, dbglutz.ConfigColumns.BatchSize)
+	omit(config.OrphanPolicy == "", dbglutz.ConfigColumns.OrphanPolicy)
+	omit(config.MissingAssetPolicy == "", dbglutz.ConfigColumns.MissingAssetPolicy)
+	omit(config.SyncAssetNames == nil, dbglutz.ConfigColumns.SyncAssetNames)
+	omit(config.BatteryLowThreshold == nil, dbglutz.ConfigColumns.BatteryLowThreshold)
+	omit(config.CommunicationErrorsThreshold == nil, dbglutz.ConfigColumns.CommunicationErrorsThreshold)
+	omit(config.StaleThreshold == 0, dbglutz.ConfigColumns.StaleThreshold)
+	omit(config.MaxConcurrentCommands == 0, dbglutz.ConfigColumns.MaxConcurrentCommands)
+	omit(config.TimeZone == nil, dbglutz.ConfigColumns.TimeZone)
+	return omitted
+}
+
+// Converts the configuration from the database. If the password cannot be decrypted, the configuration is
+// returned without password and with the connection state ConnectionStateInvalidPassword.
 func apiConfigFromDbConfig(dbConfig *dbglutz.Config) *apiserver.Configuration {
+	password, passwordErr := decryptPassword(dbConfig.Password)
 	var apiConfig apiserver.Configuration
 	apiConfig.ConfigId = dbConfig.ConfigID
 	apiConfig.Username = dbConfig.Username
-	apiConfig.Password = dbConfig.Password
+	apiConfig.Password = password
 	apiConfig.Url = dbConfig.URL
 	apiConfig.Active = &dbConfig.Active.Bool
 	apiConfig.Enable = &dbConfig.Enable.Bool
@@ -202,14 +880,51 @@ func apiConfigFromDbConfig(dbConfig *dbglutz.Config) *apiserver.Configuration {
 	apiConfig.DefaultOpenableDuration = dbConfig.DefaultOpenableDuration.Int32
 	apiConfig.Initialized = &dbConfig.Initialized.Bool
 	apiConfig.ProjIds = common.Ptr[[]string](dbConfig.ProjectIds)
+	apiConfig.BatchSize = dbConfig.BatchSize.Int32
+	if dbConfig.CycleOk.Valid {
+		apiConfig.CycleSummary = &apiserver.CycleSummary{
+			Ok:       dbConfig.CycleOk.Int32,
+			Failed:   dbConfig.CycleFailed.Int32,
+			Created:  dbConfig.CycleCreated.Int32,
+			Skipped:  dbConfig.CycleSkipped.Int32,
+			Orphaned: dbConfig.CycleOrphaned.Int32,
+		}
+	}
+	if dbConfig.ConnectionState.Valid {
+		apiConfig.Health = &apiserver.ConnectionHealth{
+			State:               dbConfig.ConnectionState.String,
+			LastSync:            dbConfig.LastSyncAt.Ptr(),
+			LastError:           dbConfig.LastError.String,
+			ConsecutiveFailures: dbConfig.ConsecutiveFailures.Int32,
+			LastCycleDuration:   dbConfig.LastCycleDuration.Int64,
+		}
+	}
+	apiConfig.OrphanPolicy = dbConfig.OrphanPolicy.String
+	apiConfig.MissingAssetPolicy = dbConfig.MissingAssetPolicy.String
+	apiConfig.SyncAssetNames = dbConfig.SyncAssetNames.Ptr()
+	apiConfig.BatteryLowThreshold = dbConfig.BatteryLowThreshold.Ptr()
+	apiConfig.CommunicationErrorsThreshold = dbConfig.CommunicationErrorsThreshold.Ptr()
+	apiConfig.StaleThreshold = dbConfig.StaleThreshold.Int32
+	apiConfig.MaxConcurrentCommands = dbConfig.MaxConcurrentCommands.Int32
+	apiConfig.TimeZone = dbConfig.TimeZone.Ptr()
+	if passwordErr != nil {
+		apiConfig.Health = &apiserver.ConnectionHealth{
+			State:     ConnectionStateInvalidPassword,
+			LastError: fmt.Sprintf("password: %v", passwordErr),
+		}
+	}
 	return &apiConfig
 }
 
-func dbConfigFromApiConfig(apiConfig *apiserver.Configuration) *dbglutz.Config {
+func dbConfigFromApiConfig(apiConfig *apiserver.Configuration) (*dbglutz.Config, error) {
+	password, err := encryptPassword(apiConfig.Password)
+	if err != nil {
+		return nil, fmt.Errorf("encrypting password: %w", err)
+	}
 	var dbConfig dbglutz.Config
 	dbConfig.ConfigID = null.Int64FromPtr(&apiConfig.ConfigId).Int64
 	dbConfig.Username = apiConfig.Username
-	dbConfig.Password = apiConfig.Password
+	dbConfig.Password = password
 	dbConfig.URL = apiConfig.Url
 	dbConfig.Active = null.BoolFromPtr(apiConfig.Active)
 	dbConfig.Enable = null.BoolFromPtr(apiConfig.Enable)
@@ -217,8 +932,17 @@ func dbConfigFromApiConfig(apiConfig *apiserver.Configuration) *dbglutz.Config {
 	dbConfig.RequestTimeout = null.Int32FromPtr(&apiConfig.RequestTimeout)
 	dbConfig.DefaultOpenableDuration = null.Int32FromPtr(&apiConfig.DefaultOpenableDuration)
 	dbConfig.Initialized = null.BoolFromPtr(apiConfig.Initialized)
+	dbConfig.BatchSize = null.Int32FromPtr(&apiConfig.BatchSize)
+	dbConfig.OrphanPolicy = null.StringFromPtr(&apiConfig.OrphanPolicy)
+	dbConfig.MissingAssetPolicy = null.StringFromPtr(&apiConfig.MissingAssetPolicy)
+	dbConfig.SyncAssetNames = null.BoolFromPtr(apiConfig.SyncAssetNames)
+	dbConfig.BatteryLowThreshold = null.Int32FromPtr(apiConfig.BatteryLowThreshold)
+	dbConfig.CommunicationErrorsThreshold = null.Int32FromPtr(apiConfig.CommunicationErrorsThreshold)
+	dbConfig.StaleThreshold = null.Int32FromPtr(&apiConfig.StaleThreshold)
+	dbConfig.MaxConcurrentCommands = null.Int32FromPtr(&apiConfig.MaxConcurrentCommands)
+	dbConfig.TimeZone = null.StringFromPtr(apiConfig.TimeZone)
 	if apiConfig.ProjIds != nil {
 		dbConfig.ProjectIds = *apiConfig.ProjIds
 	}
-	return &dbConfig
+	return &dbConfig, nil
 }
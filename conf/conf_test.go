@@ -0,0 +1,104 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"glutz/apiserver"
+	dbglutz "glutz/db/glutz"
+	"testing"
+
+	"github.com/volatiletech/null/v8"
+)
+
+func TestSyncAssetNamesDefault(t *testing.T) {
+	// A configuration stored without the flag syncs the asset names
+	apiConfig := apiConfigFromDbConfig(&dbglutz.Config{ConfigID: 1})
+	if apiConfig.SyncAssetNames != nil || !IsAssetNameSyncEnabled(*apiConfig) {
+		t.Errorf("got syncAssetNames %v, want default true", apiConfig.SyncAssetNames)
+	}
+	apiConfig = apiConfigFromDbConfig(&dbglutz.Config{ConfigID: 1, SyncAssetNames: null.BoolFrom(false)})
+	if IsAssetNameSyncEnabled(*apiConfig) {
+		t.Error("got syncAssetNames true, want stored false")
+	}
+
+	// An update without the flag keeps the stored value
+	keptSyncAssetNames := func(config apiserver.Configuration) bool {
+		for _, column := range keptConfigColumns(config) {
+			if column == dbglutz.ConfigColumns.SyncAssetNames {
+				return true
+			}
+		}
+		return false
+	}
+	if !keptSyncAssetNames(apiserver.Configuration{}) {
+		t.Error("omitted syncAssetNames overwrites the stored value")
+	}
+	syncAssetNames := false
+	if keptSyncAssetNames(apiserver.Configuration{SyncAssetNames: &syncAssetNames}) {
+		t.Error("syncAssetNames not updated")
+	}
+}
+
+func TestOmittedConfigColumns(t *testing.T) {
+	isKept := func(config apiserver.Configuration, column string) bool {
+		for _, kept := range keptConfigColumns(config) {
+			if kept == column {
+				return true
+			}
+		}
+		return false
+	}
+
+	// An update with only the required fields keeps all optional fields
+	required := apiserver.Configuration{Url: "https://glutz.example.com", Username: "user", RequestTimeout: 120, RefreshInterval: 60}
+	for _, column := range []string{
+		dbglutz.ConfigColumns.Password,
+		dbglutz.ConfigColumns.Enable,
+		dbglutz.ConfigColumns.DefaultOpenableDuration,
+		dbglutz.ConfigColumns.ProjectIds,
+		dbglutz.ConfigColumns.BatchSize,
+		dbglutz.ConfigColumns.OrphanPolicy,
+		dbglutz.ConfigColumns.MissingAssetPolicy,
+		dbglutz.ConfigColumns.SyncAssetNames,
+		dbglutz.ConfigColumns.BatteryLowThreshold,
+		dbglutz.ConfigColumns.CommunicationErrorsThreshold,
+		dbglutz.ConfigColumns.StaleThreshold,
+		dbglutz.ConfigColumns.MaxConcurrentCommands,
+		dbglutz.ConfigColumns.TimeZone,
+	} {
+		if !isKept(required, column) {
+			t.Errorf("omitted %s overwrites the stored value", column)
+		}
+	}
+	if isKept(required, dbglutz.ConfigColumns.URL) || isKept(required, dbglutz.ConfigColumns.RefreshInterval) {
+		t.Error("given fields not updated")
+	}
+
+	// Thresholds of 0 and an empty time zone are stored as given
+	zero := int32(0)
+	appTimeZone := ""
+	explicit := apiserver.Configuration{CommunicationErrorsThreshold: &zero, TimeZone: &appTimeZone}
+	if isKept(explicit, dbglutz.ConfigColumns.CommunicationErrorsThreshold) || isKept(explicit, dbglutz.ConfigColumns.TimeZone) {
+		t.Error("explicit communicationErrorsThreshold or timeZone not updated")
+	}
+
+	// A battery threshold of 0 would be replaced by the default, so it is rejected
+	required.BatteryLowThreshold = &zero
+	invalid := ValidateConfig(required)
+	if len(invalid) != 1 || invalid[0].Field != "batteryLowThreshold" {
+		t.Errorf("got invalid fields %v, want batteryLowThreshold", invalid)
+	}
+}
@@ -27,7 +27,27 @@ create table if not exists glutz.config
     refresh_interval    integer default 60,
     default_openable_duration   integer default 10,
     initialized      boolean default false,
-    project_ids          text[]
+    project_ids          text[],
+    batch_size          integer default 50,
+    cycle_ok            integer,
+    cycle_failed        integer,
+    cycle_created       integer,
+    cycle_skipped       integer,
+    orphan_policy       text default 'keep',
+    cycle_orphaned      integer,
+    missing_asset_policy    text default 'ignore',
+    sync_asset_names    boolean default true,
+    battery_low_threshold   integer default 20,
+    communication_errors_threshold  integer default 0,
+    stale_threshold     integer default 3600,
+    max_concurrent_commands integer default 4,
+    time_zone           text,
+    connection_state    text,
+    last_sync_at        timestamp with time zone,
+    last_error          text,
+    consecutive_failures    integer,
+    last_cycle_duration     bigint,
+    device_assets_migrated  boolean default false
 );
 
 create table if not exists glutz.devices
@@ -37,9 +57,64 @@ create table if not exists glutz.devices
     device_id           text not null,
     asset_id            integer not null,
     location_id         text not null,
+    state               text not null default 'active',
+    asset_name          text,
+    parent_asset_id     integer,
+    communication_errors    bigint,
     primary key(config_id, project_id, device_id)
 );
 
+create table if not exists glutz.access_points
+(
+    config_id           bigint not null,
+    project_id          text not null,
+    access_point_id     text not null,
+    asset_id            integer not null,
+    state               text not null default 'active',
+    asset_name          text,
+    parent_asset_id     integer,
+    primary key(config_id, project_id, access_point_id)
+);
+
+create table if not exists glutz.alarm_rules
+(
+    asset_id            integer not null,
+    attribute           text not null,
+    alarm_rule_id       integer not null,
+    threshold           integer not null,
+    primary key(asset_id, attribute)
+);
+
+create table if not exists glutz.commands
+(
+    command_id          bigserial primary key,
+    config_id           bigint not null,
+    asset_id            integer not null,
+    access_point_id     text not null,
+    action              text not null default 'open',
+    state               text not null,
+    duration            integer not null,
+    error               text,
+    error_count         integer not null default 0,
+    requested_at        timestamp with time zone not null,
+    sent_at             timestamp with time zone,
+    opened_at           timestamp with time zone,
+    close_at            timestamp with time zone,
+    closed_at           timestamp with time zone,
+    failed_at           timestamp with time zone
+);
+
+create index if not exists commands_state_idx on glutz.commands (state);
+
+create table if not exists glutz.door_states
+(
+    config_id           bigint not null,
+    access_point_id     text not null,
+    state               text not null,
+    command_id          bigint,
+    changed_at          timestamp with time zone not null,
+    primary key(config_id, access_point_id)
+);
 
 commit;
 
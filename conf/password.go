@@ -0,0 +1,113 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PasswordKeyEnv is the environment variable holding the base64 encoded 256 bit AES key used to encrypt the
+// passwords of the configurations in the database.
+const PasswordKeyEnv = "PASSWORD_ENCRYPTION_KEY"
+
+// Prefix of encrypted passwords in the database. Passwords without prefix are stored in plaintext.
+const encryptedPasswordPrefix = "enc:v1:"
+
+// ErrNoPasswordKey is returned if passwords should be encrypted or decrypted but no key is set.
+var ErrNoPasswordKey = errors.New(PasswordKeyEnv + " is not set")
+
+// Returns the key from the environment, nil if no key is set
+func passwordKey() ([]byte, error) {
+	value := os.Getenv(PasswordKeyEnv)
+	if value == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", PasswordKeyEnv, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s must be 32 bytes, got %d", PasswordKeyEnv, len(key))
+	}
+	return key, nil
+}
+
+func passwordCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func isEncryptedPassword(stored string) bool {
+	return strings.HasPrefix(stored, encryptedPasswordPrefix)
+}
+
+// Encrypts the password with AES-GCM for the database. Without key the password is stored in plaintext.
+func encryptPassword(password string) (string, error) {
+	key, err := passwordKey()
+	if err != nil || key == nil {
+		return password, err
+	}
+	gcm, err := passwordCipher(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := gcm.Seal(nonce, nonce, []byte(password), nil)
+	return encryptedPasswordPrefix + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypts a password read from the database. Plaintext passwords are returned unchanged.
+func decryptPassword(stored string) (string, error) {
+	if !isEncryptedPassword(stored) {
+		return stored, nil
+	}
+	key, err := passwordKey()
+	if err != nil {
+		return "", err
+	}
+	if key == nil {
+		return "", ErrNoPasswordKey
+	}
+	gcm, err := passwordCipher(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(stored, encryptedPasswordPrefix))
+	if err != nil {
+		return "", fmt.Errorf("decoding password: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("encrypted password too short")
+	}
+	password, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("decrypting password: %w", err)
+	}
+	return string(password), nil
+}
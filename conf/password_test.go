@@ -0,0 +1,90 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	dbglutz "glutz/db/glutz"
+	"testing"
+)
+
+func setPasswordKey(t *testing.T) {
+	t.Helper()
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(PasswordKeyEnv, base64.StdEncoding.EncodeToString(key))
+}
+
+func TestPasswordEncryption(t *testing.T) {
+	setPasswordKey(t)
+	stored, err := encryptPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isEncryptedPassword(stored) || stored == encryptedPasswordPrefix+"secret" {
+		t.Errorf("got stored password %s, want encrypted", stored)
+	}
+	if again, _ := encryptPassword("secret"); again == stored {
+		t.Error("expected a new nonce for each encryption")
+	}
+	if password, err := decryptPassword(stored); err != nil || password != "secret" {
+		t.Errorf("got %q, %v, want secret", password, err)
+	}
+	if password, err := decryptPassword("plain"); err != nil || password != "plain" {
+		t.Errorf("got %q, %v for plaintext password, want it unchanged", password, err)
+	}
+
+	setPasswordKey(t)
+	if _, err := decryptPassword(stored); err == nil {
+		t.Error("expected error decrypting with another key")
+	}
+	t.Setenv(PasswordKeyEnv, "")
+	if _, err := decryptPassword(stored); !errors.Is(err, ErrNoPasswordKey) {
+		t.Errorf("got error %v, want %v", err, ErrNoPasswordKey)
+	}
+	if stored, err := encryptPassword("secret"); err != nil || stored != "secret" {
+		t.Errorf("got %q, %v without key, want plaintext", stored, err)
+	}
+	t.Setenv(PasswordKeyEnv, base64.StdEncoding.EncodeToString([]byte("short")))
+	if _, err := encryptPassword("secret"); err == nil {
+		t.Error("expected error for a key with wrong length")
+	}
+}
+
+func TestUndecryptablePassword(t *testing.T) {
+	setPasswordKey(t)
+	stored, err := encryptPassword("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config := apiConfigFromDbConfig(&dbglutz.Config{ConfigID: 1, Password: stored}); PasswordError(*config) != nil || config.Password != "secret" {
+		t.Errorf("got password %q, error %v, want secret", config.Password, PasswordError(*config))
+	}
+
+	// Another key must not fail the configuration but mark it
+	setPasswordKey(t)
+	config := apiConfigFromDbConfig(&dbglutz.Config{ConfigID: 1, Password: stored})
+	if config.Password != "" || config.Health == nil || config.Health.State != ConnectionStateInvalidPassword {
+		t.Errorf("got password %q, health %+v, want state %s", config.Password, config.Health, ConnectionStateInvalidPassword)
+	}
+	if PasswordError(*config) == nil {
+		t.Error("expected password error")
+	}
+}
@@ -0,0 +1,94 @@
+--  This file is part of the eliona project.
+--  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+--  ______ _ _
+-- |  ____| (_)
+-- | |__  | |_  ___  _ __   __ _
+-- |  __| | | |/ _ \| '_ \ / _` |
+-- | |____| | | (_) | | | | (_| |
+-- |______|_|_|\___/|_| |_|\__,_|
+--
+--  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+--  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+--  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+--  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+--  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+alter table glutz.config add column if not exists batch_size integer default 50;
+alter table glutz.config add column if not exists cycle_ok integer;
+alter table glutz.config add column if not exists cycle_failed integer;
+alter table glutz.config add column if not exists cycle_created integer;
+alter table glutz.config add column if not exists cycle_skipped integer;
+alter table glutz.config add column if not exists orphan_policy text default 'keep';
+alter table glutz.config add column if not exists cycle_orphaned integer;
+alter table glutz.config add column if not exists missing_asset_policy text default 'ignore';
+alter table glutz.config add column if not exists sync_asset_names boolean default true;
+alter table glutz.config add column if not exists battery_low_threshold integer default 20;
+alter table glutz.config add column if not exists communication_errors_threshold integer default 0;
+alter table glutz.config add column if not exists stale_threshold integer default 3600;
+alter table glutz.config add column if not exists max_concurrent_commands integer default 4;
+alter table glutz.config add column if not exists time_zone text;
+alter table glutz.config add column if not exists connection_state text;
+alter table glutz.config add column if not exists last_sync_at timestamp with time zone;
+alter table glutz.config add column if not exists last_error text;
+alter table glutz.config add column if not exists consecutive_failures integer;
+alter table glutz.config add column if not exists last_cycle_duration bigint;
+alter table glutz.config add column if not exists device_assets_migrated boolean default false;
+
+alter table glutz.devices add column if not exists state text not null default 'active';
+alter table glutz.devices add column if not exists asset_name text;
+alter table glutz.devices add column if not exists parent_asset_id integer;
+alter table glutz.devices add column if not exists communication_errors bigint;
+
+create table if not exists glutz.access_points
+(
+    config_id           bigint not null,
+    project_id          text not null,
+    access_point_id     text not null,
+    asset_id            integer not null,
+    state               text not null default 'active',
+    asset_name          text,
+    parent_asset_id     integer,
+    primary key(config_id, project_id, access_point_id)
+);
+
+create table if not exists glutz.alarm_rules
+(
+    asset_id            integer not null,
+    attribute           text not null,
+    alarm_rule_id       integer not null,
+    threshold           integer not null,
+    primary key(asset_id, attribute)
+);
+
+create table if not exists glutz.commands
+(
+    command_id          bigserial primary key,
+    config_id           bigint not null,
+    asset_id            integer not null,
+    access_point_id     text not null,
+    action              text not null default 'open',
+    state               text not null,
+    duration            integer not null,
+    error               text,
+    error_count         integer not null default 0,
+    requested_at        timestamp with time zone not null,
+    sent_at             timestamp with time zone,
+    opened_at           timestamp with time zone,
+    close_at            timestamp with time zone,
+    closed_at           timestamp with time zone,
+    failed_at           timestamp with time zone
+);
+
+create index if not exists commands_state_idx on glutz.commands (state);
+
+create table if not exists glutz.door_states
+(
+    config_id           bigint not null,
+    access_point_id     text not null,
+    state               text not null,
+    command_id          bigint,
+    changed_at          timestamp with time zone not null,
+    primary key(config_id, access_point_id)
+);
+
+commit;
@@ -0,0 +1,113 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package conf
+
+import (
+	"fmt"
+	"glutz/apiserver"
+	"net/url"
+	"time"
+)
+
+// Limits of the numeric fields of a configuration
+const (
+	maxRequestTimeout  = 3600
+	maxRefreshInterval = 86400
+)
+
+// ValidateConfig checks the fields of a configuration written through the API and returns every invalid
+// field. The existence of the projects in Eliona is not checked.
+func ValidateConfig(config apiserver.Configuration) []apiserver.FieldError {
+	var invalid []apiserver.FieldError
+	add := func(field string, format string, args ...interface{}) {
+		invalid = append(invalid, apiserver.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if config.Url == "" {
+		add("url", "is required")
+	} else if err := validateUrl(config.Url); err != nil {
+		add("url", "%v", err)
+	}
+	if config.Username == "" {
+		add("username", "is required")
+	}
+	if config.RequestTimeout < 1 || config.RequestTimeout > maxRequestTimeout {
+		add("requestTimeout", "must be between 1 and %d seconds", maxRequestTimeout)
+	}
+	if config.RefreshInterval < 1 || config.RefreshInterval > maxRefreshInterval {
+		add("refreshInterval", "must be between 1 and %d seconds", maxRefreshInterval)
+	}
+	if config.DefaultOpenableDuration < 0 {
+		add("defaultOpenableDuration", "must not be negative")
+	}
+	if config.BatchSize < 0 {
+		add("batchSize", "must not be negative")
+	}
+	if config.BatteryLowThreshold != nil && (*config.BatteryLowThreshold < 1 || *config.BatteryLowThreshold > 100) {
+		add("batteryLowThreshold", "must be between 1 and 100 percent")
+	}
+	if config.CommunicationErrorsThreshold != nil && *config.CommunicationErrorsThreshold < 0 {
+		add("communicationErrorsThreshold", "must not be negative")
+	}
+	if config.StaleThreshold < 0 {
+		add("staleThreshold", "must not be negative")
+	}
+	if config.MaxConcurrentCommands < 0 {
+		add("maxConcurrentCommands", "must not be negative")
+	}
+	if config.TimeZone != nil && *config.TimeZone != "" {
+		if _, err := time.LoadLocation(*config.TimeZone); err != nil {
+			add("timeZone", "is no known time zone")
+		}
+	}
+	switch config.OrphanPolicy {
+	case "", OrphanPolicyKeep, OrphanPolicyMark, OrphanPolicyDelete:
+	default:
+		add("orphanPolicy", "must be one of %s, %s or %s", OrphanPolicyKeep, OrphanPolicyMark, OrphanPolicyDelete)
+	}
+	switch config.MissingAssetPolicy {
+	case "", MissingAssetPolicyIgnore, MissingAssetPolicyRecreate:
+	default:
+		add("missingAssetPolicy", "must be one of %s or %s", MissingAssetPolicyIgnore, MissingAssetPolicyRecreate)
+	}
+	if config.ProjIds != nil {
+		for _, projectId := range *config.ProjIds {
+			if projectId == "" {
+				add("projIds", "must not contain empty project ids")
+				break
+			}
+		}
+	}
+	return invalid
+}
+
+// The URL of the Glutz server without the path of the JSON-RPC endpoint, e.g. https://glutz.example.com
+func validateUrl(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("is no valid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("must be an http or https URL")
+	}
+	if u.Host == "" {
+		return fmt.Errorf("has no host")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("must not contain a query or fragment")
+	}
+	return nil
+}
@@ -4,9 +4,17 @@
 package dbglutz
 
 var TableNames = struct {
-	Config  string
-	Devices string
+	AccessPoints string
+	AlarmRules   string
+	Commands     string
+	Config       string
+	Devices      string
+	DoorStates   string
 }{
-	Config:  "config",
-	Devices: "devices",
+	AccessPoints: "access_points",
+	AlarmRules:   "alarm_rules",
+	Commands:     "commands",
+	Config:       "config",
+	Devices:      "devices",
+	DoorStates:   "door_states",
 }
@@ -25,122 +25,176 @@ import (
 
 // Config is an object representing the database table.
 type Config struct {
-	ConfigID                int64             `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
-	Username                string            `boil:"username" json:"username" toml:"username" yaml:"username"`
-	Password                string            `boil:"password" json:"password" toml:"password" yaml:"password"`
-	URL                     string            `boil:"url" json:"url" toml:"url" yaml:"url"`
-	Active                  null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
-	Enable                  null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
-	RequestTimeout          null.Int32        `boil:"request_timeout" json:"request_timeout,omitempty" toml:"request_timeout" yaml:"request_timeout,omitempty"`
-	RefreshInterval         null.Int32        `boil:"refresh_interval" json:"refresh_interval,omitempty" toml:"refresh_interval" yaml:"refresh_interval,omitempty"`
-	DefaultOpenableDuration null.Int32        `boil:"default_openable_duration" json:"default_openable_duration,omitempty" toml:"default_openable_duration" yaml:"default_openable_duration,omitempty"`
-	Initialized             null.Bool         `boil:"initialized" json:"initialized,omitempty" toml:"initialized" yaml:"initialized,omitempty"`
-	ProjectIds              types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
+	ConfigID                     int64             `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
+	Username                     string            `boil:"username" json:"username" toml:"username" yaml:"username"`
+	Password                     string            `boil:"password" json:"password" toml:"password" yaml:"password"`
+	URL                          string            `boil:"url" json:"url" toml:"url" yaml:"url"`
+	Active                       null.Bool         `boil:"active" json:"active,omitempty" toml:"active" yaml:"active,omitempty"`
+	Enable                       null.Bool         `boil:"enable" json:"enable,omitempty" toml:"enable" yaml:"enable,omitempty"`
+	RequestTimeout               null.Int32        `boil:"request_timeout" json:"request_timeout,omitempty" toml:"request_timeout" yaml:"request_timeout,omitempty"`
+	RefreshInterval              null.Int32        `boil:"refresh_interval" json:"refresh_interval,omitempty" toml:"refresh_interval" yaml:"refresh_interval,omitempty"`
+	DefaultOpenableDuration      null.Int32        `boil:"default_openable_duration" json:"default_openable_duration,omitempty" toml:"default_openable_duration" yaml:"default_openable_duration,omitempty"`
+	Initialized                  null.Bool         `boil:"initialized" json:"initialized,omitempty" toml:"initialized" yaml:"initialized,omitempty"`
+	ProjectIds                   types.StringArray `boil:"project_ids" json:"project_ids,omitempty" toml:"project_ids" yaml:"project_ids,omitempty"`
+	BatchSize                    null.Int32        `boil:"batch_size" json:"batch_size,omitempty" toml:"batch_size" yaml:"batch_size,omitempty"`
+	CycleOk                      null.Int32        `boil:"cycle_ok" json:"cycle_ok,omitempty" toml:"cycle_ok" yaml:"cycle_ok,omitempty"`
+	CycleFailed                  null.Int32        `boil:"cycle_failed" json:"cycle_failed,omitempty" toml:"cycle_failed" yaml:"cycle_failed,omitempty"`
+	CycleCreated                 null.Int32        `boil:"cycle_created" json:"cycle_created,omitempty" toml:"cycle_created" yaml:"cycle_created,omitempty"`
+	CycleSkipped                 null.Int32        `boil:"cycle_skipped" json:"cycle_skipped,omitempty" toml:"cycle_skipped" yaml:"cycle_skipped,omitempty"`
+	OrphanPolicy                 null.String       `boil:"orphan_policy" json:"orphan_policy,omitempty" toml:"orphan_policy" yaml:"orphan_policy,omitempty"`
+	CycleOrphaned                null.Int32        `boil:"cycle_orphaned" json:"cycle_orphaned,omitempty" toml:"cycle_orphaned" yaml:"cycle_orphaned,omitempty"`
+	MissingAssetPolicy           null.String       `boil:"missing_asset_policy" json:"missing_asset_policy,omitempty" toml:"missing_asset_policy" yaml:"missing_asset_policy,omitempty"`
+	SyncAssetNames               null.Bool         `boil:"sync_asset_names" json:"sync_asset_names,omitempty" toml:"sync_asset_names" yaml:"sync_asset_names,omitempty"`
+	BatteryLowThreshold          null.Int32        `boil:"battery_low_threshold" json:"battery_low_threshold,omitempty" toml:"battery_low_threshold" yaml:"battery_low_threshold,omitempty"`
+	CommunicationErrorsThreshold null.Int32        `boil:"communication_errors_threshold" json:"communication_errors_threshold,omitempty" toml:"communication_errors_threshold" yaml:"communication_errors_threshold,omitempty"`
+	StaleThreshold               null.Int32        `boil:"stale_threshold" json:"stale_threshold,omitempty" toml:"stale_threshold" yaml:"stale_threshold,omitempty"`
+	MaxConcurrentCommands        null.Int32        `boil:"max_concurrent_commands" json:"max_concurrent_commands,omitempty" toml:"max_concurrent_commands" yaml:"max_concurrent_commands,omitempty"`
+	TimeZone                     null.String       `boil:"time_zone" json:"time_zone,omitempty" toml:"time_zone" yaml:"time_zone,omitempty"`
+	ConnectionState              null.String       `boil:"connection_state" json:"connection_state,omitempty" toml:"connection_state" yaml:"connection_state,omitempty"`
+	LastSyncAt                   null.Time         `boil:"last_sync_at" json:"last_sync_at,omitempty" toml:"last_sync_at" yaml:"last_sync_at,omitempty"`
+	LastError                    null.String       `boil:"last_error" json:"last_error,omitempty" toml:"last_error" yaml:"last_error,omitempty"`
+	ConsecutiveFailures          null.Int32        `boil:"consecutive_failures" json:"consecutive_failures,omitempty" toml:"consecutive_failures" yaml:"consecutive_failures,omitempty"`
+	LastCycleDuration            null.Int64        `boil:"last_cycle_duration" json:"last_cycle_duration,omitempty" toml:"last_cycle_duration" yaml:"last_cycle_duration,omitempty"`
+	DeviceAssetsMigrated         null.Bool         `boil:"device_assets_migrated" json:"device_assets_migrated,omitempty" toml:"device_assets_migrated" yaml:"device_assets_migrated,omitempty"`
 
 	R *configR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L configL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var ConfigColumns = struct {
-	ConfigID                string
-	Username                string
-	Password                string
-	URL                     string
-	Active                  string
-	Enable                  string
-	RequestTimeout          string
-	RefreshInterval         string
-	DefaultOpenableDuration string
-	Initialized             string
-	ProjectIds              string
+	ConfigID                     string
+	Username                     string
+	Password                     string
+	URL                          string
+	Active                       string
+	Enable                       string
+	RequestTimeout               string
+	RefreshInterval              string
+	DefaultOpenableDuration      string
+	Initialized                  string
+	ProjectIds                   string
+	BatchSize                    string
+	CycleOk                      string
+	CycleFailed                  string
+	CycleCreated                 string
+	CycleSkipped                 string
+	OrphanPolicy                 string
+	CycleOrphaned                string
+	MissingAssetPolicy           string
+	SyncAssetNames               string
+	BatteryLowThreshold          string
+	CommunicationErrorsThreshold string
+	StaleThreshold               string
+	MaxConcurrentCommands        string
+	TimeZone                     string
+	ConnectionState              string
+	LastSyncAt                   string
+	LastError                    string
+	ConsecutiveFailures          string
+	LastCycleDuration            string
+	DeviceAssetsMigrated         string
 }{
-	ConfigID:                "config_id",
-	Username:                "username",
-	Password:                "password",
-	URL:                     "url",
-	Active:                  "active",
-	Enable:                  "enable",
-	RequestTimeout:          "request_timeout",
-	RefreshInterval:         "refresh_interval",
-	DefaultOpenableDuration: "default_openable_duration",
-	Initialized:             "initialized",
-	ProjectIds:              "project_ids",
+	ConfigID:                     "config_id",
+	Username:                     "username",
+	Password:                     "password",
+	URL:                          "url",
+	Active:                       "active",
+	Enable:                       "enable",
+	RequestTimeout:               "request_timeout",
+	RefreshInterval:              "refresh_interval",
+	DefaultOpenableDuration:      "default_openable_duration",
+	Initialized:                  "initialized",
+	ProjectIds:                   "project_ids",
+	BatchSize:                    "batch_size",
+	CycleOk:                      "cycle_ok",
+	CycleFailed:                  "cycle_failed",
+	CycleCreated:                 "cycle_created",
+	CycleSkipped:                 "cycle_skipped",
+	OrphanPolicy:                 "orphan_policy",
+	CycleOrphaned:                "cycle_orphaned",
+	MissingAssetPolicy:           "missing_asset_policy",
+	SyncAssetNames:               "sync_asset_names",
+	BatteryLowThreshold:          "battery_low_threshold",
+	CommunicationErrorsThreshold: "communication_errors_threshold",
+	StaleThreshold:               "stale_threshold",
+	MaxConcurrentCommands:        "max_concurrent_commands",
+	TimeZone:                     "time_zone",
+	ConnectionState:              "connection_state",
+	LastSyncAt:                   "last_sync_at",
+	LastError:                    "last_error",
+	ConsecutiveFailures:          "consecutive_failures",
+	LastCycleDuration:            "last_cycle_duration",
+	DeviceAssetsMigrated:         "device_assets_migrated",
 }
 
 var ConfigTableColumns = struct {
-	ConfigID                string
-	Username                string
-	Password                string
-	URL                     string
-	Active                  string
-	Enable                  string
-	RequestTimeout          string
-	RefreshInterval         string
-	DefaultOpenableDuration string
-	Initialized             string
-	ProjectIds              string
+	ConfigID                     string
+	Username                     string
+	Password                     string
+	URL                          string
+	Active                       string
+	Enable                       string
+	RequestTimeout               string
+	RefreshInterval              string
+	DefaultOpenableDuration      string
+	Initialized                  string
+	ProjectIds                   string
+	BatchSize                    string
+	CycleOk                      string
+	CycleFailed                  string
+	CycleCreated                 string
+	CycleSkipped                 string
+	OrphanPolicy                 string
+	CycleOrphaned                string
+	MissingAssetPolicy           string
+	SyncAssetNames               string
+	BatteryLowThreshold          string
+	CommunicationErrorsThreshold string
+	StaleThreshold               string
+	MaxConcurrentCommands        string
+	TimeZone                     string
+	ConnectionState              string
+	LastSyncAt                   string
+	LastError                    string
+	ConsecutiveFailures          string
+	LastCycleDuration            string
+	DeviceAssetsMigrated         string
 }{
-	ConfigID:                "config.config_id",
-	Username:                "config.username",
-	Password:                "config.password",
-	URL:                     "config.url",
-	Active:                  "config.active",
-	Enable:                  "config.enable",
-	RequestTimeout:          "config.request_timeout",
-	RefreshInterval:         "config.refresh_interval",
-	DefaultOpenableDuration: "config.default_openable_duration",
-	Initialized:             "config.initialized",
-	ProjectIds:              "config.project_ids",
+	ConfigID:                     "config.config_id",
+	Username:                     "config.username",
+	Password:                     "config.password",
+	URL:                          "config.url",
+	Active:                       "config.active",
+	Enable:                       "config.enable",
+	RequestTimeout:               "config.request_timeout",
+	RefreshInterval:              "config.refresh_interval",
+	DefaultOpenableDuration:      "config.default_openable_duration",
+	Initialized:                  "config.initialized",
+	ProjectIds:                   "config.project_ids",
+	BatchSize:                    "config.batch_size",
+	CycleOk:                      "config.cycle_ok",
+	CycleFailed:                  "config.cycle_failed",
+	CycleCreated:                 "config.cycle_created",
+	CycleSkipped:                 "config.cycle_skipped",
+	OrphanPolicy:                 "config.orphan_policy",
+	CycleOrphaned:                "config.cycle_orphaned",
+	MissingAssetPolicy:           "config.missing_asset_policy",
+	SyncAssetNames:               "config.sync_asset_names",
+	BatteryLowThreshold:          "config.battery_low_threshold",
+	CommunicationErrorsThreshold: "config.communication_errors_threshold",
+	StaleThreshold:               "config.stale_threshold",
+	MaxConcurrentCommands:        "config.max_concurrent_commands",
+	TimeZone:                     "config.time_zone",
+	ConnectionState:              "config.connection_state",
+	LastSyncAt:                   "config.last_sync_at",
+	LastError:                    "config.last_error",
+	ConsecutiveFailures:          "config.consecutive_failures",
+	LastCycleDuration:            "config.last_cycle_duration",
+	DeviceAssetsMigrated:         "config.device_assets_migrated",
 }
 
 // Generated where
 
-type whereHelperint64 struct{ field string }
-
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 type whereHelpernull_Bool struct{ field string }
 
 func (w whereHelpernull_Bool) EQ(x null.Bool) qm.QueryMod {
@@ -165,94 +219,134 @@ func (w whereHelpernull_Bool) GTE(x null.Bool) qm.QueryMod {
 func (w whereHelpernull_Bool) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
 func (w whereHelpernull_Bool) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
-type whereHelpernull_Int32 struct{ field string }
+type whereHelpertypes_StringArray struct{ field string }
 
-func (w whereHelpernull_Int32) EQ(x null.Int32) qm.QueryMod {
+func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_Int32) NEQ(x null.Int32) qm.QueryMod {
+func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Int32) LT(x null.Int32) qm.QueryMod {
+func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_Int32) LTE(x null.Int32) qm.QueryMod {
+func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_Int32) GT(x null.Int32) qm.QueryMod {
+func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_Int32) GTE(x null.Int32) qm.QueryMod {
+func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
-func (w whereHelpernull_Int32) IN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelpernull_Int32) NIN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
 
-func (w whereHelpernull_Int32) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Int32) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpertypes_StringArray) IsNull() qm.QueryMod { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
+	return qmhelper.WhereIsNotNull(w.field)
+}
 
-type whereHelpertypes_StringArray struct{ field string }
+type whereHelpernull_Int64 struct{ field string }
 
-func (w whereHelpertypes_StringArray) EQ(x types.StringArray) qm.QueryMod {
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpertypes_StringArray) NEQ(x types.StringArray) qm.QueryMod {
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpertypes_StringArray) LT(x types.StringArray) qm.QueryMod {
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpertypes_StringArray) LTE(x types.StringArray) qm.QueryMod {
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpertypes_StringArray) GT(x types.StringArray) qm.QueryMod {
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpertypes_StringArray) GTE(x types.StringArray) qm.QueryMod {
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
-
-func (w whereHelpertypes_StringArray) IsNull() qm.QueryMod { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpertypes_StringArray) IsNotNull() qm.QueryMod {
-	return qmhelper.WhereIsNotNull(w.field)
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
+}
+
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var ConfigWhere = struct {
-	ConfigID                whereHelperint64
-	Username                whereHelperstring
-	Password                whereHelperstring
-	URL                     whereHelperstring
-	Active                  whereHelpernull_Bool
-	Enable                  whereHelpernull_Bool
-	RequestTimeout          whereHelpernull_Int32
-	RefreshInterval         whereHelpernull_Int32
-	DefaultOpenableDuration whereHelpernull_Int32
-	Initialized             whereHelpernull_Bool
-	ProjectIds              whereHelpertypes_StringArray
+	ConfigID                     whereHelperint64
+	Username                     whereHelperstring
+	Password                     whereHelperstring
+	URL                          whereHelperstring
+	Active                       whereHelpernull_Bool
+	Enable                       whereHelpernull_Bool
+	RequestTimeout               whereHelpernull_Int32
+	RefreshInterval              whereHelpernull_Int32
+	DefaultOpenableDuration      whereHelpernull_Int32
+	Initialized                  whereHelpernull_Bool
+	ProjectIds                   whereHelpertypes_StringArray
+	BatchSize                    whereHelpernull_Int32
+	CycleOk                      whereHelpernull_Int32
+	CycleFailed                  whereHelpernull_Int32
+	CycleCreated                 whereHelpernull_Int32
+	CycleSkipped                 whereHelpernull_Int32
+	OrphanPolicy                 whereHelpernull_String
+	CycleOrphaned                whereHelpernull_Int32
+	MissingAssetPolicy           whereHelpernull_String
+	SyncAssetNames               whereHelpernull_Bool
+	BatteryLowThreshold          whereHelpernull_Int32
+	CommunicationErrorsThreshold whereHelpernull_Int32
+	StaleThreshold               whereHelpernull_Int32
+	MaxConcurrentCommands        whereHelpernull_Int32
+	TimeZone                     whereHelpernull_String
+	ConnectionState              whereHelpernull_String
+	LastSyncAt                   whereHelpernull_Time
+	LastError                    whereHelpernull_String
+	ConsecutiveFailures          whereHelpernull_Int32
+	LastCycleDuration            whereHelpernull_Int64
+	DeviceAssetsMigrated         whereHelpernull_Bool
 }{
-	ConfigID:                whereHelperint64{field: "\"glutz\".\"config\".\"config_id\""},
-	Username:                whereHelperstring{field: "\"glutz\".\"config\".\"username\""},
-	Password:                whereHelperstring{field: "\"glutz\".\"config\".\"password\""},
-	URL:                     whereHelperstring{field: "\"glutz\".\"config\".\"url\""},
-	Active:                  whereHelpernull_Bool{field: "\"glutz\".\"config\".\"active\""},
-	Enable:                  whereHelpernull_Bool{field: "\"glutz\".\"config\".\"enable\""},
-	RequestTimeout:          whereHelpernull_Int32{field: "\"glutz\".\"config\".\"request_timeout\""},
-	RefreshInterval:         whereHelpernull_Int32{field: "\"glutz\".\"config\".\"refresh_interval\""},
-	DefaultOpenableDuration: whereHelpernull_Int32{field: "\"glutz\".\"config\".\"default_openable_duration\""},
-	Initialized:             whereHelpernull_Bool{field: "\"glutz\".\"config\".\"initialized\""},
-	ProjectIds:              whereHelpertypes_StringArray{field: "\"glutz\".\"config\".\"project_ids\""},
+	ConfigID:                     whereHelperint64{field: "\"glutz\".\"config\".\"config_id\""},
+	Username:                     whereHelperstring{field: "\"glutz\".\"config\".\"username\""},
+	Password:                     whereHelperstring{field: "\"glutz\".\"config\".\"password\""},
+	URL:                          whereHelperstring{field: "\"glutz\".\"config\".\"url\""},
+	Active:                       whereHelpernull_Bool{field: "\"glutz\".\"config\".\"active\""},
+	Enable:                       whereHelpernull_Bool{field: "\"glutz\".\"config\".\"enable\""},
+	RequestTimeout:               whereHelpernull_Int32{field: "\"glutz\".\"config\".\"request_timeout\""},
+	RefreshInterval:              whereHelpernull_Int32{field: "\"glutz\".\"config\".\"refresh_interval\""},
+	DefaultOpenableDuration:      whereHelpernull_Int32{field: "\"glutz\".\"config\".\"default_openable_duration\""},
+	Initialized:                  whereHelpernull_Bool{field: "\"glutz\".\"config\".\"initialized\""},
+	ProjectIds:                   whereHelpertypes_StringArray{field: "\"glutz\".\"config\".\"project_ids\""},
+	BatchSize:                    whereHelpernull_Int32{field: "\"glutz\".\"config\".\"batch_size\""},
+	CycleOk:                      whereHelpernull_Int32{field: "\"glutz\".\"config\".\"cycle_ok\""},
+	CycleFailed:                  whereHelpernull_Int32{field: "\"glutz\".\"config\".\"cycle_failed\""},
+	CycleCreated:                 whereHelpernull_Int32{field: "\"glutz\".\"config\".\"cycle_created\""},
+	CycleSkipped:                 whereHelpernull_Int32{field: "\"glutz\".\"config\".\"cycle_skipped\""},
+	OrphanPolicy:                 whereHelpernull_String{field: "\"glutz\".\"config\".\"orphan_policy\""},
+	CycleOrphaned:                whereHelpernull_Int32{field: "\"glutz\".\"config\".\"cycle_orphaned\""},
+	MissingAssetPolicy:           whereHelpernull_String{field: "\"glutz\".\"config\".\"missing_asset_policy\""},
+	SyncAssetNames:               whereHelpernull_Bool{field: "\"glutz\".\"config\".\"sync_asset_names\""},
+	BatteryLowThreshold:          whereHelpernull_Int32{field: "\"glutz\".\"config\".\"battery_low_threshold\""},
+	CommunicationErrorsThreshold: whereHelpernull_Int32{field: "\"glutz\".\"config\".\"communication_errors_threshold\""},
+	StaleThreshold:               whereHelpernull_Int32{field: "\"glutz\".\"config\".\"stale_threshold\""},
+	MaxConcurrentCommands:        whereHelpernull_Int32{field: "\"glutz\".\"config\".\"max_concurrent_commands\""},
+	TimeZone:                     whereHelpernull_String{field: "\"glutz\".\"config\".\"time_zone\""},
+	ConnectionState:              whereHelpernull_String{field: "\"glutz\".\"config\".\"connection_state\""},
+	LastSyncAt:                   whereHelpernull_Time{field: "\"glutz\".\"config\".\"last_sync_at\""},
+	LastError:                    whereHelpernull_String{field: "\"glutz\".\"config\".\"last_error\""},
+	ConsecutiveFailures:          whereHelpernull_Int32{field: "\"glutz\".\"config\".\"consecutive_failures\""},
+	LastCycleDuration:            whereHelpernull_Int64{field: "\"glutz\".\"config\".\"last_cycle_duration\""},
+	DeviceAssetsMigrated:         whereHelpernull_Bool{field: "\"glutz\".\"config\".\"device_assets_migrated\""},
 }
 
 // ConfigRels is where relationship names are stored.
@@ -272,9 +366,9 @@ func (*configR) NewStruct() *configR {
 type configL struct{}
 
 var (
-	configAllColumns            = []string{"config_id", "username", "password", "url", "active", "enable", "request_timeout", "refresh_interval", "default_openable_duration", "initialized", "project_ids"}
-	configColumnsWithoutDefault = []string{"username", "password", "url"}
-	configColumnsWithDefault    = []string{"config_id", "active", "enable", "request_timeout", "refresh_interval", "default_openable_duration", "initialized", "project_ids"}
+	configAllColumns            = []string{"config_id", "username", "password", "url", "active", "enable", "request_timeout", "refresh_interval", "default_openable_duration", "initialized", "project_ids", "batch_size", "cycle_ok", "cycle_failed", "cycle_created", "cycle_skipped", "orphan_policy", "cycle_orphaned", "missing_asset_policy", "sync_asset_names", "battery_low_threshold", "communication_errors_threshold", "stale_threshold", "max_concurrent_commands", "time_zone", "connection_state", "last_sync_at", "last_error", "consecutive_failures", "last_cycle_duration", "device_assets_migrated"}
+	configColumnsWithoutDefault = []string{"username", "password", "url", "cycle_ok", "cycle_failed", "cycle_created", "cycle_skipped", "cycle_orphaned", "time_zone", "connection_state", "last_sync_at", "last_error", "consecutive_failures", "last_cycle_duration"}
+	configColumnsWithDefault    = []string{"config_id", "active", "enable", "request_timeout", "refresh_interval", "default_openable_duration", "initialized", "project_ids", "batch_size", "orphan_policy", "missing_asset_policy", "sync_asset_names", "battery_low_threshold", "communication_errors_threshold", "stale_threshold", "max_concurrent_commands", "device_assets_migrated"}
 	configPrimaryKeyColumns     = []string{"config_id"}
 	configGeneratedColumns      = []string{}
 )
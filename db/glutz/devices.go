@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,81 +24,86 @@ import (
 
 // Device is an object representing the database table.
 type Device struct {
-	ConfigID   int64  `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
-	ProjectID  string `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
-	DeviceID   string `boil:"device_id" json:"device_id" toml:"device_id" yaml:"device_id"`
-	AssetID    int32  `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
-	LocationID string `boil:"location_id" json:"location_id" toml:"location_id" yaml:"location_id"`
+	ConfigID            int64       `boil:"config_id" json:"config_id" toml:"config_id" yaml:"config_id"`
+	ProjectID           string      `boil:"project_id" json:"project_id" toml:"project_id" yaml:"project_id"`
+	DeviceID            string      `boil:"device_id" json:"device_id" toml:"device_id" yaml:"device_id"`
+	AssetID             int32       `boil:"asset_id" json:"asset_id" toml:"asset_id" yaml:"asset_id"`
+	LocationID          string      `boil:"location_id" json:"location_id" toml:"location_id" yaml:"location_id"`
+	State               string      `boil:"state" json:"state" toml:"state" yaml:"state"`
+	AssetName           null.String `boil:"asset_name" json:"asset_name,omitempty" toml:"asset_name" yaml:"asset_name,omitempty"`
+	ParentAssetID       null.Int32  `boil:"parent_asset_id" json:"parent_asset_id,omitempty" toml:"parent_asset_id" yaml:"parent_asset_id,omitempty"`
+	CommunicationErrors null.Int64  `boil:"communication_errors" json:"communication_errors,omitempty" toml:"communication_errors" yaml:"communication_errors,omitempty"`
 
 	R *deviceR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L deviceL  `boil:"-" json:"-" toml:"-" yaml:"-"`
 }
 
 var DeviceColumns = struct {
-	ConfigID   string
-	ProjectID  string
-	DeviceID   string
-	AssetID    string
-	LocationID string
+	ConfigID            string
+	ProjectID           string
+	DeviceID            string
+	AssetID             string
+	LocationID          string
+	State               string
+	AssetName           string
+	ParentAssetID       string
+	CommunicationErrors string
 }{
-	ConfigID:   "config_id",
-	ProjectID:  "project_id",
-	DeviceID:   "device_id",
-	AssetID:    "asset_id",
-	LocationID: "location_id",
+	ConfigID:            "config_id",
+	ProjectID:           "project_id",
+	DeviceID:            "device_id",
+	AssetID:             "asset_id",
+	LocationID:          "location_id",
+	State:               "state",
+	AssetName:           "asset_name",
+	ParentAssetID:       "parent_asset_id",
+	CommunicationErrors: "communication_errors",
 }
 
 var DeviceTableColumns = struct {
-	ConfigID   string
-	ProjectID  string
-	DeviceID   string
-	AssetID    string
-	LocationID string
+	ConfigID            string
+	ProjectID           string
+	DeviceID            string
+	AssetID             string
+	LocationID          string
+	State               string
+	AssetName           string
+	ParentAssetID       string
+	CommunicationErrors string
 }{
-	ConfigID:   "devices.config_id",
-	ProjectID:  "devices.project_id",
-	DeviceID:   "devices.device_id",
-	AssetID:    "devices.asset_id",
-	LocationID: "devices.location_id",
+	ConfigID:            "devices.config_id",
+	ProjectID:           "devices.project_id",
+	DeviceID:            "devices.device_id",
+	AssetID:             "devices.asset_id",
+	LocationID:          "devices.location_id",
+	State:               "devices.state",
+	AssetName:           "devices.asset_name",
+	ParentAssetID:       "devices.parent_asset_id",
+	CommunicationErrors: "devices.communication_errors",
 }
 
 // Generated where
 
-type whereHelperint32 struct{ field string }
-
-func (w whereHelperint32) EQ(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint32) NEQ(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint32) LT(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint32) LTE(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint32) GT(x int32) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint32) GTE(x int32) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint32) IN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
-}
-func (w whereHelperint32) NIN(slice []int32) qm.QueryMod {
-	values := make([]interface{}, 0, len(slice))
-	for _, value := range slice {
-		values = append(values, value)
-	}
-	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
-}
-
 var DeviceWhere = struct {
-	ConfigID   whereHelperint64
-	ProjectID  whereHelperstring
-	DeviceID   whereHelperstring
-	AssetID    whereHelperint32
-	LocationID whereHelperstring
+	ConfigID            whereHelperint64
+	ProjectID           whereHelperstring
+	DeviceID            whereHelperstring
+	AssetID             whereHelperint32
+	LocationID          whereHelperstring
+	State               whereHelperstring
+	AssetName           whereHelpernull_String
+	ParentAssetID       whereHelpernull_Int32
+	CommunicationErrors whereHelpernull_Int64
 }{
-	ConfigID:   whereHelperint64{field: "\"glutz\".\"devices\".\"config_id\""},
-	ProjectID:  whereHelperstring{field: "\"glutz\".\"devices\".\"project_id\""},
-	DeviceID:   whereHelperstring{field: "\"glutz\".\"devices\".\"device_id\""},
-	AssetID:    whereHelperint32{field: "\"glutz\".\"devices\".\"asset_id\""},
-	LocationID: whereHelperstring{field: "\"glutz\".\"devices\".\"location_id\""},
+	ConfigID:            whereHelperint64{field: "\"glutz\".\"devices\".\"config_id\""},
+	ProjectID:           whereHelperstring{field: "\"glutz\".\"devices\".\"project_id\""},
+	DeviceID:            whereHelperstring{field: "\"glutz\".\"devices\".\"device_id\""},
+	AssetID:             whereHelperint32{field: "\"glutz\".\"devices\".\"asset_id\""},
+	LocationID:          whereHelperstring{field: "\"glutz\".\"devices\".\"location_id\""},
+	State:               whereHelperstring{field: "\"glutz\".\"devices\".\"state\""},
+	AssetName:           whereHelpernull_String{field: "\"glutz\".\"devices\".\"asset_name\""},
+	ParentAssetID:       whereHelpernull_Int32{field: "\"glutz\".\"devices\".\"parent_asset_id\""},
+	CommunicationErrors: whereHelpernull_Int64{field: "\"glutz\".\"devices\".\"communication_errors\""},
 }
 
 // DeviceRels is where relationship names are stored.
@@ -117,9 +123,9 @@ func (*deviceR) NewStruct() *deviceR {
 type deviceL struct{}
 
 var (
-	deviceAllColumns            = []string{"config_id", "project_id", "device_id", "asset_id", "location_id"}
-	deviceColumnsWithoutDefault = []string{"config_id", "project_id", "device_id", "asset_id", "location_id"}
-	deviceColumnsWithDefault    = []string{}
+	deviceAllColumns            = []string{"config_id", "project_id", "device_id", "asset_id", "location_id", "state", "asset_name", "parent_asset_id", "communication_errors"}
+	deviceColumnsWithoutDefault = []string{"config_id", "project_id", "device_id", "asset_id", "location_id", "asset_name", "parent_asset_id", "communication_errors"}
+	deviceColumnsWithDefault    = []string{"state"}
 	devicePrimaryKeyColumns     = []string{"config_id", "project_id", "device_id"}
 	deviceGeneratedColumns      = []string{}
 )
@@ -0,0 +1,221 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"glutz/apiserver"
+	"glutz/conf"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-utils/log"
+)
+
+// Number of workers handling the outputs of all configurations
+const outputWorkers = 16
+
+// Output for an access point, resolved from the asset id of the output
+type outputJob struct {
+	output      api.Data
+	accessPoint apiserver.AccessPoint
+	config      apiserver.Configuration
+}
+
+type accessPointKey struct {
+	configId      int64
+	accessPointId string
+}
+
+func (job outputJob) key() accessPointKey {
+	return accessPointKey{configId: int64(job.accessPoint.ConfigId), accessPointId: job.accessPoint.AccessPointId}
+}
+
+// Handles the outputs received from Eliona concurrently with a pool of workers. Outputs for the same access
+// point are handled one after another in the order received, and at most MaxConcurrentCommands outputs of a
+// configuration are handled at the same time. Workers never wait for a busy access point or configuration,
+// so a slow Glutz server only delays the doors of its own configuration. The access points of the outputs
+// are resolved one after another by a single resolver, so they are queued in the order received.
+type outputDispatcher struct {
+	resolve func(ctx context.Context, output api.Data) (*apiserver.AccessPoint, *apiserver.Configuration, error)
+	handle  func(ctx context.Context, accessPoint apiserver.AccessPoint, config apiserver.Configuration, output api.Data)
+
+	mutex    sync.Mutex
+	changed  *sync.Cond
+	received []api.Data                     // outputs not yet resolved to an access point
+	pending  map[accessPointKey][]outputJob // resolved outputs waiting per access point
+	order    []accessPointKey               // access points with pending outputs in the order they were received
+	busy     map[accessPointKey]bool        // access points whose output is currently handled
+	running  map[int64]int                  // number of outputs currently handled per configuration
+	stopped  bool
+	resolved bool // all received outputs are resolved after the dispatcher was stopped
+	workers  sync.WaitGroup
+}
+
+func newOutputDispatcher(
+	resolve func(ctx context.Context, output api.Data) (*apiserver.AccessPoint, *apiserver.Configuration, error),
+	handle func(ctx context.Context, accessPoint apiserver.AccessPoint, config apiserver.Configuration, output api.Data),
+) *outputDispatcher {
+	d := &outputDispatcher{
+		resolve: resolve,
+		handle:  handle,
+		pending: make(map[accessPointKey][]outputJob),
+		busy:    make(map[accessPointKey]bool),
+		running: make(map[int64]int),
+	}
+	d.changed = sync.NewCond(&d.mutex)
+	return d
+}
+
+// Starts the resolver and the given number of workers
+func (d *outputDispatcher) start(workers int) {
+	d.workers.Add(1)
+	go d.resolveAll()
+	for i := 0; i < workers; i++ {
+		d.workers.Add(1)
+		go d.work()
+	}
+}
+
+// Queues the output to be handled by the workers. The call doesn't block.
+func (d *outputDispatcher) dispatch(output api.Data) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.received = append(d.received, output)
+	d.changed.Broadcast()
+}
+
+// Stops the workers after all queued outputs are handled
+func (d *outputDispatcher) stop() {
+	d.mutex.Lock()
+	d.stopped = true
+	d.changed.Broadcast()
+	d.mutex.Unlock()
+	d.workers.Wait()
+}
+
+func (d *outputDispatcher) work() {
+	defer d.workers.Done()
+	for {
+		job, ok := d.next()
+		if !ok {
+			return
+		}
+		d.run(job)
+	}
+}
+
+// Waits for the next resolved output whose access point and configuration are free. Returns false if the
+// dispatcher is stopped and all outputs are handled.
+func (d *outputDispatcher) next() (outputJob, bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	for {
+		if job, ok := d.nextJob(); ok {
+			return job, true
+		}
+		if d.resolved && len(d.pending) == 0 {
+			return outputJob{}, false
+		}
+		d.changed.Wait()
+	}
+}
+
+// Takes the first pending output whose access point and configuration are free. Must be called with the
+// mutex locked.
+func (d *outputDispatcher) nextJob() (outputJob, bool) {
+	for i, key := range d.order {
+		jobs := d.pending[key]
+		job := jobs[0]
+		if d.busy[key] || d.running[key.configId] >= conf.MaxConcurrentCommands(job.config) {
+			continue
+		}
+		if len(jobs) == 1 {
+			delete(d.pending, key)
+			d.order = append(d.order[:i], d.order[i+1:]...)
+		} else {
+			d.pending[key] = jobs[1:]
+		}
+		d.busy[key] = true
+		d.running[key.configId]++
+		return job, true
+	}
+	return outputJob{}, false
+}
+
+// Resolves the received outputs one after another until the dispatcher is stopped and all received outputs
+// are resolved
+func (d *outputDispatcher) resolveAll() {
+	defer d.workers.Done()
+	for {
+		d.mutex.Lock()
+		for len(d.received) == 0 && !d.stopped {
+			d.changed.Wait()
+		}
+		if len(d.received) == 0 {
+			d.resolved = true
+			d.changed.Broadcast()
+			d.mutex.Unlock()
+			return
+		}
+		output := d.received[0]
+		d.received = d.received[1:]
+		d.mutex.Unlock()
+		d.resolveOutput(output)
+	}
+}
+
+// Resolves the access point of the output and queues it behind the outputs received before, also if
+// resolving panics
+func (d *outputDispatcher) resolveOutput(output api.Data) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Output", "Panic resolving output of asset %d: %v", output.AssetId, r)
+		}
+	}()
+	accessPoint, config, err := d.resolve(context.Background(), output)
+	if err != nil || accessPoint == nil || config == nil {
+		return
+	}
+	job := outputJob{output: output, accessPoint: *accessPoint, config: *config}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	key := job.key()
+	if _, ok := d.pending[key]; !ok {
+		d.order = append(d.order, key)
+	}
+	d.pending[key] = append(d.pending[key], job)
+	d.changed.Broadcast()
+}
+
+// Handles the output and frees its access point and configuration again, also if handling panics
+func (d *outputDispatcher) run(job outputJob) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("Output", "Panic handling output of asset %d: %v", job.output.AssetId, r)
+		}
+		d.mutex.Lock()
+		defer d.mutex.Unlock()
+		key := job.key()
+		delete(d.busy, key)
+		d.running[key.configId]--
+		if d.running[key.configId] == 0 {
+			delete(d.running, key.configId)
+		}
+		d.changed.Broadcast()
+	}()
+	d.handle(context.Background(), job.accessPoint, job.config, job.output)
+}
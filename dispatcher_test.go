@@ -0,0 +1,210 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"glutz/apiserver"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+// testDispatcher resolves the asset id of an output to access point "ap-<asset id>" of the configuration
+// given in the output data. It records the outputs handled per access point and the maximum number of
+// outputs handled at the same time per configuration. With delay set, resolving an output takes up to 2ms.
+type testDispatcher struct {
+	*outputDispatcher
+	configs map[int32]apiserver.Configuration
+	block   map[int32]chan struct{}
+	delay   bool
+
+	mutex      sync.Mutex
+	handled    map[string][]int
+	running    map[int32]int
+	maxRunning map[int32]int
+	concurrent map[string]bool
+	overlap    bool
+}
+
+func newTestDispatcher(configs ...apiserver.Configuration) *testDispatcher {
+	td := &testDispatcher{
+		configs:    make(map[int32]apiserver.Configuration),
+		block:      make(map[int32]chan struct{}),
+		handled:    make(map[string][]int),
+		running:    make(map[int32]int),
+		maxRunning: make(map[int32]int),
+		concurrent: make(map[string]bool),
+	}
+	for _, config := range configs {
+		td.configs[int32(config.ConfigId)] = config
+	}
+	td.outputDispatcher = newOutputDispatcher(td.resolve, td.handle)
+	return td
+}
+
+func testOutput(configId int32, assetId int32, sequence int) api.Data {
+	return api.Data{AssetId: assetId, Data: map[string]interface{}{"config": configId, "sequence": sequence}}
+}
+
+func (td *testDispatcher) resolve(_ context.Context, output api.Data) (*apiserver.AccessPoint, *apiserver.Configuration, error) {
+	if td.delay {
+		time.Sleep(time.Duration(rand.Intn(2000)) * time.Microsecond)
+	}
+	config, ok := td.configs[output.Data["config"].(int32)]
+	if !ok {
+		return nil, nil, nil
+	}
+	return &apiserver.AccessPoint{
+		ConfigId:      int32(config.ConfigId),
+		AssetId:       output.AssetId,
+		AccessPointId: fmt.Sprintf("ap-%d", output.AssetId),
+	}, &config, nil
+}
+
+func (td *testDispatcher) handle(_ context.Context, accessPoint apiserver.AccessPoint, config apiserver.Configuration, output api.Data) {
+	key := fmt.Sprintf("%d/%s", config.ConfigId, accessPoint.AccessPointId)
+	configId := int32(config.ConfigId)
+	td.mutex.Lock()
+	if td.concurrent[key] {
+		td.overlap = true
+	}
+	td.concurrent[key] = true
+	td.running[configId]++
+	if td.running[configId] > td.maxRunning[configId] {
+		td.maxRunning[configId] = td.running[configId]
+	}
+	block := td.block[configId]
+	td.mutex.Unlock()
+
+	if block != nil {
+		<-block
+	}
+	time.Sleep(5 * time.Millisecond)
+
+	td.mutex.Lock()
+	defer td.mutex.Unlock()
+	td.concurrent[key] = false
+	td.running[configId]--
+	td.handled[key] = append(td.handled[key], output.Data["sequence"].(int))
+	if output.Data["panic"] != nil {
+		panic("handling failed")
+	}
+}
+
+func TestDispatchSameAccessPoint(t *testing.T) {
+	td := newTestDispatcher(apiserver.Configuration{ConfigId: 1, MaxConcurrentCommands: 4})
+	td.delay = true
+	td.start(8)
+	for sequence := 1; sequence <= 10; sequence++ {
+		td.dispatch(testOutput(1, 1, sequence))
+	}
+	td.stop()
+
+	if td.overlap {
+		t.Error("outputs for the same access point were handled concurrently")
+	}
+	handled := td.handled["1/ap-1"]
+	if len(handled) != 10 {
+		t.Fatalf("got %d handled outputs, want 10", len(handled))
+	}
+	for i, sequence := range handled {
+		if sequence != i+1 {
+			t.Errorf("got outputs handled in order %v, want order received", handled)
+			break
+		}
+	}
+}
+
+func TestDispatchConfigLimit(t *testing.T) {
+	td := newTestDispatcher(
+		apiserver.Configuration{ConfigId: 1, MaxConcurrentCommands: 2},
+		apiserver.Configuration{ConfigId: 2},
+	)
+	td.start(16)
+	for assetId := int32(1); assetId <= 8; assetId++ {
+		td.dispatch(testOutput(1, assetId, 1))
+		td.dispatch(testOutput(2, assetId, 1))
+	}
+	td.stop()
+
+	if td.maxRunning[1] != 2 {
+		t.Errorf("got %d concurrent outputs of config 1, want limit 2", td.maxRunning[1])
+	}
+	if td.maxRunning[2] != 4 {
+		t.Errorf("got %d concurrent outputs of config 2, want default limit 4", td.maxRunning[2])
+	}
+	if len(td.handled) != 16 {
+		t.Errorf("got outputs handled for %d access points, want 16", len(td.handled))
+	}
+}
+
+func TestDispatchSlowConfig(t *testing.T) {
+	td := newTestDispatcher(
+		apiserver.Configuration{ConfigId: 1, MaxConcurrentCommands: 1},
+		apiserver.Configuration{ConfigId: 2},
+	)
+	blocked := make(chan struct{})
+	td.block[1] = blocked
+	td.start(2)
+
+	// Outputs of a blocked Glutz server don't occupy the workers
+	for assetId := int32(1); assetId <= 5; assetId++ {
+		td.dispatch(testOutput(1, assetId, 1))
+	}
+	td.dispatch(testOutput(2, 1, 1))
+	deadline := time.Now().Add(time.Second)
+	for {
+		td.mutex.Lock()
+		handled := len(td.handled["2/ap-1"])
+		td.mutex.Unlock()
+		if handled == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("output of config 2 not handled while config 1 is blocked")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(blocked)
+	td.stop()
+	for assetId := 1; assetId <= 5; assetId++ {
+		if len(td.handled[fmt.Sprintf("1/ap-%d", assetId)]) != 1 {
+			t.Errorf("output for access point ap-%d of config 1 not handled", assetId)
+		}
+	}
+}
+
+func TestDispatchPanic(t *testing.T) {
+	td := newTestDispatcher(apiserver.Configuration{ConfigId: 1, MaxConcurrentCommands: 1})
+	td.start(1)
+	output := testOutput(1, 1, 1)
+	output.Data["panic"] = true
+	td.dispatch(output)
+	td.dispatch(testOutput(1, 1, 2))
+	td.dispatch(testOutput(3, 1, 3))
+	td.stop()
+
+	// The access point and configuration are freed again after a panic, unknown assets are ignored
+	if handled := td.handled["1/ap-1"]; len(handled) != 2 {
+		t.Errorf("got handled outputs %v, want 2", handled)
+	}
+}
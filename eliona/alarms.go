@@ -0,0 +1,127 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+	"github.com/eliona-smart-building-assistant/go-eliona-api-client/v2/tools"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
+	"github.com/eliona-smart-building-assistant/go-utils/common"
+)
+
+// DeviceAlarmRule is an alarm rule for an attribute of a device asset. Threshold is the configured limit the
+// rule is built from, it is stored to detect changed configurations.
+type DeviceAlarmRule struct {
+	api.AlarmRule
+	Threshold int32
+}
+
+// DeviceAlarmRules returns the alarm rules of a device asset: battery level below the given threshold, the
+// battery alarm of the device, more new communication errors than the given threshold, any last error and
+// no update of the device within the given stale threshold.
+func DeviceAlarmRules(assetId int32, batteryLowThreshold int32, communicationErrorsThreshold int32, staleThreshold time.Duration) []DeviceAlarmRule {
+	batteryLow := deviceAlarmRule(assetId, "batteryLevel", api.ALARM_PRIORITY_MEDIUM,
+		fmt.Sprintf("Battery level below %d%%", batteryLowThreshold),
+		fmt.Sprintf("Batteriestand unter %d%%", batteryLowThreshold))
+	batteryLow.Low = limit(batteryLowThreshold)
+
+	batteryAlarm := deviceAlarmRule(assetId, "batteryAlarm", api.ALARM_PRIORITY_HEIGHT,
+		"Battery alarm reported by the device",
+		"Batteriealarm vom Gerät gemeldet")
+	batteryAlarm.Equal = limit(1)
+
+	communicationErrors := deviceAlarmRule(assetId, "communicationErrorsIncrease", api.ALARM_PRIORITY_LOW,
+		fmt.Sprintf("More than %d new communication errors", communicationErrorsThreshold),
+		fmt.Sprintf("Mehr als %d neue Kommunikationsfehler", communicationErrorsThreshold))
+	communicationErrors.High = limit(communicationErrorsThreshold)
+
+	lastError := deviceAlarmRule(assetId, "lastError", api.ALARM_PRIORITY_MEDIUM,
+		"Error reported by the device",
+		"Fehler vom Gerät gemeldet")
+	lastError.High = limit(0)
+
+	offline := deviceAlarmRule(assetId, "online", api.ALARM_PRIORITY_HEIGHT,
+		fmt.Sprintf("No update from the device for more than %d minutes", int(staleThreshold.Minutes())),
+		fmt.Sprintf("Keine Aktualisierung vom Gerät seit mehr als %d Minuten", int(staleThreshold.Minutes())))
+	offline.Equal = limit(0)
+
+	return []DeviceAlarmRule{
+		{AlarmRule: batteryLow, Threshold: batteryLowThreshold},
+		{AlarmRule: batteryAlarm, Threshold: 1},
+		{AlarmRule: communicationErrors, Threshold: communicationErrorsThreshold},
+		{AlarmRule: lastError, Threshold: 0},
+		{AlarmRule: offline, Threshold: int32(staleThreshold.Seconds())},
+	}
+}
+
+func deviceAlarmRule(assetId int32, attribute string, priority api.AlarmPriority, en string, de string) api.AlarmRule {
+	return api.AlarmRule{
+		AssetId:             assetId,
+		Subtype:             api.SUBTYPE_INPUT,
+		Attribute:           attribute,
+		Enable:              common.Ptr(true),
+		Priority:            priority,
+		RequiresAcknowledge: common.Ptr(false),
+		Message:             map[string]interface{}{"en": en, "de": de},
+		Subject:             *api.NewNullableString(common.Ptr("Glutz")),
+	}
+}
+
+func limit(value int32) api.NullableFloat64 {
+	return *api.NewNullableFloat64(common.Ptr(float64(value)))
+}
+
+// UpsertAlarmRule updates the alarm rule with the id of the rule or creates it if the rule has no id or
+// no longer exists. It returns the id of the rule.
+func (c *Client) UpsertAlarmRule(ctx context.Context, rule api.AlarmRule) (int32, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	if id := rule.Id.Get(); id != nil {
+		updated, resp, err := client.NewClient().AlarmRulesAPI.
+			PutAlarmRuleById(ctx, *id).
+			AlarmRule(rule).
+			Execute()
+		if resp == nil || resp.StatusCode != http.StatusNotFound {
+			tools.LogError(err)
+			if err != nil {
+				return 0, err
+			}
+			return alarmRuleId(updated)
+		}
+		rule.Id.Unset()
+	}
+	created, _, err := client.NewClient().AlarmRulesAPI.
+		PostAlarmRule(ctx).
+		AlarmRule(rule).
+		Execute()
+	tools.LogError(err)
+	if err != nil {
+		return 0, err
+	}
+	return alarmRuleId(created)
+}
+
+func alarmRuleId(rule *api.AlarmRule) (int32, error) {
+	if rule == nil || rule.Id.Get() == nil {
+		return 0, fmt.Errorf("no alarm rule id returned")
+	}
+	return *rule.Id.Get(), nil
+}
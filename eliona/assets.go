@@ -16,24 +16,196 @@
 package eliona
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"time"
+
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
-	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona-api-client/v2/tools"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 )
 
-func CreateNewAsset(projectId string, deviceid string, assetname string) (int32, error) {
-	assetId, err := asset.UpsertAsset(api.Asset{
+// Api is the part of the Eliona API used by the app. It is implemented by Client and by Fake for tests.
+type Api interface {
+	// UpsertAsset creates or updates the asset identified by project and global asset identifier and returns its id.
+	UpsertAsset(ctx context.Context, asset api.Asset) (int32, error)
+	// ExistAsset checks if the asset with the given id exists.
+	ExistAsset(ctx context.Context, assetId int32) (bool, error)
+	// GetAssets returns all assets of the project.
+	GetAssets(ctx context.Context, projectId string) ([]api.Asset, error)
+	// DeleteAsset deletes the asset with the given id. Deleting an asset which does not exist is no error.
+	DeleteAsset(ctx context.Context, assetId int32) error
+	// SetGlobalAssetId changes the global asset identifier of the asset with the given id and keeps all other
+	// fields. Changing an asset which does not exist is no error.
+	SetGlobalAssetId(ctx context.Context, assetId int32, globalAssetId string) error
+	// UpsertData writes data for an asset.
+	UpsertData(ctx context.Context, data api.Data) error
+	// UpsertAlarmRule updates the alarm rule with the id of the rule or creates it if the rule has no id or no
+	// longer exists. It returns the id of the rule.
+	UpsertAlarmRule(ctx context.Context, rule api.AlarmRule) (int32, error)
+	// ExistProject checks if the project with the given id exists.
+	ExistProject(ctx context.Context, projectId string) (bool, error)
+}
+
+// Client accesses the Eliona API. Each request is limited by the timeout of the client.
+type Client struct {
+	timeout time.Duration
+}
+
+// NewClient creates a client which limits each request to Eliona by the given timeout.
+func NewClient(timeout time.Duration) *Client {
+	return &Client{timeout: timeout}
+}
+
+func (c *Client) requestContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	return client.AuthenticationContextWrap(ctx), cancel
+}
+
+// Asset types created by the app
+const (
+	DeviceAssetType      = "glutz_device"
+	AccessPointAssetType = "glutz_access_point"
+	BuildingAssetType    = "glutz_building"
+	RoomAssetType        = "glutz_room"
+)
+
+// UpsertDeviceAsset creates or updates the asset of a Glutz device in the given project and returns its id.
+// A parent asset id other than 0 places the asset under this asset in the location tree.
+func UpsertDeviceAsset(ctx context.Context, el Api, projectId string, globalAssetId string, assetname string, parentAssetId int32) (int32, error) {
+	return upsertAsset(ctx, el, projectId, DeviceAssetType, globalAssetId, assetname, parentAssetId)
+}
+
+// UpsertAccessPointAsset creates or updates the asset of a Glutz access point in the given project and returns its id.
+func UpsertAccessPointAsset(ctx context.Context, el Api, projectId string, globalAssetId string, assetname string, parentAssetId int32) (int32, error) {
+	return upsertAsset(ctx, el, projectId, AccessPointAssetType, globalAssetId, assetname, parentAssetId)
+}
+
+// UpsertLocationAsset creates or updates a building or room asset in the given project and returns its id.
+func UpsertLocationAsset(ctx context.Context, el Api, projectId string, assetType string, globalAssetId string, name string, parentAssetId int32) (int32, error) {
+	return upsertAsset(ctx, el, projectId, assetType, globalAssetId, name, parentAssetId)
+}
+
+func upsertAsset(ctx context.Context, el Api, projectId string, assetType string, globalAssetId string, name string, parentAssetId int32) (int32, error) {
+	asset := api.Asset{
 		ProjectId:             projectId,
-		GlobalAssetIdentifier: deviceid,
-		Name:                  *api.NewNullableString(common.Ptr(assetname)),
-		AssetType:             "glutz_device",
-	})
+		GlobalAssetIdentifier: globalAssetId,
+		Name:                  *api.NewNullableString(common.Ptr(name)),
+		AssetType:             assetType,
+	}
+	if parentAssetId != 0 {
+		asset.ParentLocationalAssetId = *api.NewNullableInt32(common.Ptr(parentAssetId))
+	}
+	assetId, err := el.UpsertAsset(ctx, asset)
+	if err != nil {
+		return 0, fmt.Errorf("cannot upsert asset %s: %w", name, err)
+	}
+	return assetId, nil
+}
+
+// UpsertAsset creates or updates the asset identified by project and global asset identifier.
+func (c *Client) UpsertAsset(ctx context.Context, asset api.Asset) (int32, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	upserted, _, err := client.NewClient().AssetsAPI.
+		PutAsset(ctx).
+		Asset(asset).
+		Execute()
+	tools.LogError(err)
 	if err != nil {
 		return 0, err
 	}
-	if assetId == nil {
-		return 0, fmt.Errorf("cannot create asset: %s", assetname)
+	if upserted == nil || upserted.Id.Get() == nil {
+		return 0, fmt.Errorf("no asset id returned for %s", asset.GlobalAssetIdentifier)
+	}
+	return *upserted.Id.Get(), nil
+}
+
+// ExistAsset checks if the asset with the given id still exists in Eliona.
+func (c *Client) ExistAsset(ctx context.Context, assetId int32) (bool, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	asset, resp, err := client.NewClient().AssetsAPI.
+		GetAssetById(ctx, assetId).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	tools.LogError(err)
+	if err != nil {
+		return false, err
+	}
+	return asset != nil, nil
+}
+
+// GetAssets returns all assets of the project.
+func (c *Client) GetAssets(ctx context.Context, projectId string) ([]api.Asset, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	assets, _, err := client.NewClient().AssetsAPI.
+		GetAssets(ctx).
+		ProjectId(projectId).
+		Execute()
+	tools.LogError(err)
+	return assets, err
+}
+
+// SetGlobalAssetId reads the asset and writes it back identified by its id with the new global asset identifier.
+func (c *Client) SetGlobalAssetId(ctx context.Context, assetId int32, globalAssetId string) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	asset, resp, err := client.NewClient().AssetsAPI.
+		GetAssetById(ctx, assetId).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	tools.LogError(err)
+	if err != nil {
+		return err
+	}
+	if asset.GlobalAssetIdentifier == globalAssetId {
+		return nil
+	}
+	asset.GlobalAssetIdentifier = globalAssetId
+	_, _, err = client.NewClient().AssetsAPI.
+		PutAsset(ctx).
+		Asset(*asset).
+		IdentifyBy(string(api.ASSET_IDENTIFY_BY_ID)).
+		Execute()
+	tools.LogError(err)
+	return err
+}
+
+// ExistProject checks if the project with the given id exists.
+func (c *Client) ExistProject(ctx context.Context, projectId string) (bool, error) {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	project, resp, err := client.NewClient().ProjectsAPI.
+		GetProjectById(ctx, projectId).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	tools.LogError(err)
+	if err != nil {
+		return false, err
+	}
+	return project != nil, nil
+}
+
+// DeleteAsset deletes the asset with the given id. Deleting an asset which does not exist is no error.
+func (c *Client) DeleteAsset(ctx context.Context, assetId int32) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	resp, err := client.NewClient().AssetsAPI.
+		DeleteAssetById(ctx, assetId).
+		Execute()
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
 	}
-	return *assetId, nil
+	tools.LogError(err)
+	return err
 }
@@ -15,7 +15,7 @@ func GlutzDashboard(projectId string) (api.Dashboard, error) {
 
 	assets, _, err := client.NewClient().AssetsAPI.
 		GetAssets(client.AuthenticationContext()).
-		AssetTypeName("glutz_device").
+		AssetTypeName(AccessPointAssetType).
 		ProjectId(projectId).
 		Execute()
 	if err != nil {
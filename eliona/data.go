@@ -1,40 +1,79 @@
 package eliona
 
 import (
+	"context"
 	"fmt"
 	"glutz/glutz"
 	"time"
 
 	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
-	"github.com/eliona-smart-building-assistant/go-eliona/asset"
+	"github.com/eliona-smart-building-assistant/go-eliona-api-client/v2/tools"
+	"github.com/eliona-smart-building-assistant/go-eliona/client"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
 	"github.com/eliona-smart-building-assistant/go-utils/log"
 )
 
 type deviceInputDataPayload struct {
-	BatteryLevel int64 `json:"batteryLevel"`
-	Openings     int64 `json:"openings"`
+	BatteryLevel                int64  `json:"batteryLevel"`
+	Openings                    int64  `json:"openings"`
+	BatteryAlarm                int32  `json:"batteryAlarm"`
+	CommunicationErrors         int64  `json:"communicationErrors"`
+	CommunicationErrorsIncrease int64  `json:"communicationErrorsIncrease"`
+	IrWakeups                   int64  `json:"irWakeups"`
+	RfWakeups                   int64  `json:"rfWakeups"`
+	LastError                   int64  `json:"lastError"`
+	LastErrorTextEn             string `json:"lastErrorTextEn"`
+	LastErrorTextDe             string `json:"lastErrorTextDe"`
+	Online                      int32  `json:"online"`
 }
 
 type deviceInfoDataPayload struct {
-	Building      string `json:"building"`
-	Room          string `json:"room"`
-	AccessPoint   string `json:"accessPoint"`
-	OperatingMode int64  `json:"operatingMode"`
-	Firmware      string `json:"firmware"`
+	OperatingMode       int64  `json:"operatingMode"`
+	OperatingModeTextEn string `json:"operatingModeTextEn"`
+	OperatingModeTextDe string `json:"operatingModeTextDe"`
+	Firmware            string `json:"firmware"`
+	BatteryPowered      int32  `json:"batteryPowered"`
+	LastUpdate          string `json:"lastUpdate"`
+	Orphaned            int32  `json:"orphaned"`
+}
+
+type accessPointInfoDataPayload struct {
+	Building    string `json:"building"`
+	Room        string `json:"room"`
+	AccessPoint string `json:"accessPoint"`
+	Orphaned    int32  `json:"orphaned"`
+}
+
+type orphanedDataPayload struct {
+	Orphaned int32 `json:"orphaned"`
 }
 
 type openableDataPayload struct {
 	Openable int32 `json:"openable"`
 }
 
-func UpsertInputData(deviceData glutz.DeviceDb, assetId int32) error {
+// DeviceHealth is the state of a device the app derives from the device status.
+type DeviceHealth struct {
+	CommunicationErrorsIncrease int64
+	Online                      bool
+}
+
+func UpsertInputData(ctx context.Context, el Api, deviceData glutz.DeviceDb, health DeviceHealth, assetId int32) error {
 	log.Debug("Data", "Uploading input data")
 	deviceInput := deviceInputDataPayload{
-		BatteryLevel: deviceData.BatteryLevel,
-		Openings:     deviceData.Openings,
+		BatteryLevel:                deviceData.BatteryLevel,
+		Openings:                    deviceData.Openings,
+		BatteryAlarm:                flag(deviceData.BatteryAlarm),
+		CommunicationErrors:         deviceData.CommunicationErrors,
+		CommunicationErrorsIncrease: health.CommunicationErrorsIncrease,
+		IrWakeups:                   deviceData.IrWakeups,
+		RfWakeups:                   deviceData.RfWakeups,
+		LastError:                   deviceData.LastError,
+		LastErrorTextEn:             glutz.ErrorText(deviceData.LastError).En,
+		LastErrorTextDe:             glutz.ErrorText(deviceData.LastError).De,
+		Online:                      flag(health.Online),
 	}
-	err := upsertData(api.SUBTYPE_INPUT, assetId, deviceInput)
+	err := upsertData(ctx, el, api.SUBTYPE_INPUT, assetId, deviceInput)
 	if err != nil {
 		log.Error("Data", "Error sending input data")
 		return err
@@ -42,16 +81,17 @@ func UpsertInputData(deviceData glutz.DeviceDb, assetId int32) error {
 	return nil
 }
 
-func UpsertInfoData(deviceData glutz.DeviceDb, assetId int32) error {
+func UpsertInfoData(ctx context.Context, el Api, deviceData glutz.DeviceDb, assetId int32) error {
 	log.Debug("Data", "Uploading info data")
 	deviceInfo := deviceInfoDataPayload{
-		Building:      deviceData.Building,
-		Room:          deviceData.Room,
-		AccessPoint:   deviceData.AccessPoint,
-		OperatingMode: deviceData.OperatingMode,
-		Firmware:      deviceData.Firmware,
+		OperatingMode:       deviceData.OperatingMode,
+		OperatingModeTextEn: glutz.OperatingModeText(deviceData.OperatingMode).En,
+		OperatingModeTextDe: glutz.OperatingModeText(deviceData.OperatingMode).De,
+		Firmware:            deviceData.Firmware,
+		BatteryPowered:      flag(deviceData.BatteryPowered),
+		LastUpdate:          deviceData.LastUpdate,
 	}
-	err := upsertData(api.SUBTYPE_INFO, assetId, deviceInfo)
+	err := upsertData(ctx, el, api.SUBTYPE_INFO, assetId, deviceInfo)
 	if err != nil {
 		log.Error("Data", "Error sending info data")
 		return err
@@ -60,12 +100,28 @@ func UpsertInfoData(deviceData glutz.DeviceDb, assetId int32) error {
 
 }
 
-func UpsertOpenData(openable int32, assetId int32) error {
+// UpsertAccessPointInfoData writes the location of the access point of the device to the access point asset.
+func UpsertAccessPointInfoData(ctx context.Context, el Api, deviceData glutz.DeviceDb, assetId int32) error {
+	log.Debug("Data", "Uploading access point info data")
+	accessPointInfo := accessPointInfoDataPayload{
+		Building:    deviceData.Building,
+		Room:        deviceData.Room,
+		AccessPoint: deviceData.AccessPoint,
+	}
+	err := upsertData(ctx, el, api.SUBTYPE_INFO, assetId, accessPointInfo)
+	if err != nil {
+		log.Error("Data", "Error sending info data")
+		return err
+	}
+	return nil
+}
+
+func UpsertOpenData(ctx context.Context, el Api, openable int32, assetId int32) error {
 	log.Debug("Data", "Uploading open data")
 	deviceOpen := openableDataPayload{
 		Openable: openable,
 	}
-	err := upsertData(api.SUBTYPE_INPUT, assetId, deviceOpen)
+	err := upsertData(ctx, el, api.SUBTYPE_INPUT, assetId, deviceOpen)
 	if err != nil {
 		log.Error("Data", "Error sending input data")
 		return err
@@ -73,15 +129,54 @@ func UpsertOpenData(openable int32, assetId int32) error {
 	return nil
 }
 
-func upsertData(subtype api.DataSubtype, assetId int32, payload any) error {
+// UpsertOrphanedData marks the asset of a device no longer reported by the Glutz server as orphaned.
+func UpsertOrphanedData(ctx context.Context, el Api, assetId int32) error {
+	log.Debug("Data", "Uploading orphaned data")
+	err := upsertData(ctx, el, api.SUBTYPE_INFO, assetId, orphanedDataPayload{Orphaned: 1})
+	if err != nil {
+		log.Error("Data", "Error sending info data")
+		return err
+	}
+	return nil
+}
+
+// Eliona attributes hold numbers, flags are written as 0 or 1
+func flag(value bool) int32 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+func upsertData(ctx context.Context, el Api, subtype api.DataSubtype, assetId int32, payload any) error {
 	var statusData api.Data
 	statusData.Subtype = subtype
 	now := time.Now()
 	statusData.Timestamp = *api.NewNullableTime(&now)
 	statusData.AssetId = assetId
 	statusData.Data = common.StructToMap(payload)
-	if err := asset.UpsertDataIfAssetExists(statusData); err != nil {
+	exists, err := el.ExistAsset(ctx, assetId)
+	if err != nil {
+		return fmt.Errorf("checking asset: %v", err)
+	}
+	if !exists {
+		return nil
+	}
+	err = el.UpsertData(ctx, statusData)
+	if err != nil {
 		return fmt.Errorf("upserting data: %v", err)
 	}
 	return nil
 }
+
+// UpsertData writes data for an asset.
+func (c *Client) UpsertData(ctx context.Context, data api.Data) error {
+	ctx, cancel := c.requestContext(ctx)
+	defer cancel()
+	_, err := client.NewClient().DataAPI.
+		PutData(ctx).
+		Data(data).
+		Execute()
+	tools.LogError(err)
+	return err
+}
@@ -0,0 +1,187 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package eliona
+
+import (
+	"context"
+	"sync"
+
+	api "github.com/eliona-smart-building-assistant/go-eliona-api-client/v2"
+)
+
+// Fake is an in-memory implementation of Api for tests.
+type Fake struct {
+	mu       sync.Mutex
+	nextId   int32
+	assets   map[int32]api.Asset
+	data     map[int32]map[api.DataSubtype]map[string]interface{}
+	rules    map[int32]api.AlarmRule
+	projects map[string]bool
+}
+
+// NewFake creates an empty fake Eliona.
+func NewFake() *Fake {
+	return &Fake{
+		assets:   make(map[int32]api.Asset),
+		data:     make(map[int32]map[api.DataSubtype]map[string]interface{}),
+		rules:    make(map[int32]api.AlarmRule),
+		projects: make(map[string]bool),
+	}
+}
+
+// AddProject adds a project reported as existing by ExistProject.
+func (f *Fake) AddProject(projectId string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.projects[projectId] = true
+}
+
+func (f *Fake) ExistProject(_ context.Context, projectId string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.projects[projectId], nil
+}
+
+func (f *Fake) UpsertAsset(_ context.Context, asset api.Asset) (int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for id, existing := range f.assets {
+		if existing.ProjectId == asset.ProjectId && existing.GlobalAssetIdentifier == asset.GlobalAssetIdentifier {
+			asset.Id = *api.NewNullableInt32(&id)
+			f.assets[id] = asset
+			return id, nil
+		}
+	}
+	f.nextId++
+	id := f.nextId
+	asset.Id = *api.NewNullableInt32(&id)
+	f.assets[id] = asset
+	return id, nil
+}
+
+func (f *Fake) GetAssets(_ context.Context, projectId string) ([]api.Asset, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var assets []api.Asset
+	for _, asset := range f.assets {
+		if asset.ProjectId == projectId {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, nil
+}
+
+func (f *Fake) SetGlobalAssetId(_ context.Context, assetId int32, globalAssetId string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if asset, ok := f.assets[assetId]; ok {
+		asset.GlobalAssetIdentifier = globalAssetId
+		f.assets[assetId] = asset
+	}
+	return nil
+}
+
+func (f *Fake) ExistAsset(_ context.Context, assetId int32) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.assets[assetId]
+	return ok, nil
+}
+
+// UpsertData merges the data into the current data of the asset, like Eliona does.
+func (f *Fake) UpsertData(_ context.Context, data api.Data) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.data[data.AssetId] == nil {
+		f.data[data.AssetId] = make(map[api.DataSubtype]map[string]interface{})
+	}
+	current := f.data[data.AssetId][data.Subtype]
+	if current == nil {
+		current = make(map[string]interface{})
+		f.data[data.AssetId][data.Subtype] = current
+	}
+	for key, value := range data.Data {
+		current[key] = value
+	}
+	return nil
+}
+
+// Data returns the current data of the given subtype of an asset, nil if there is no data.
+func (f *Fake) Data(assetId int32, subtype api.DataSubtype) map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	current := f.data[assetId][subtype]
+	if current == nil {
+		return nil
+	}
+	data := make(map[string]interface{}, len(current))
+	for key, value := range current {
+		data[key] = value
+	}
+	return data
+}
+
+func (f *Fake) UpsertAlarmRule(_ context.Context, rule api.AlarmRule) (int32, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id := rule.Id.Get(); id != nil {
+		if _, ok := f.rules[*id]; ok {
+			f.rules[*id] = rule
+			return *id, nil
+		}
+	}
+	f.nextId++
+	id := f.nextId
+	rule.Id = *api.NewNullableInt32(&id)
+	f.rules[id] = rule
+	return id, nil
+}
+
+// AlarmRules returns all alarm rules stored in the fake.
+func (f *Fake) AlarmRules() []api.AlarmRule {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	rules := make([]api.AlarmRule, 0, len(f.rules))
+	for _, rule := range f.rules {
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// Assets returns all assets stored in the fake.
+func (f *Fake) Assets() []api.Asset {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	assets := make([]api.Asset, 0, len(f.assets))
+	for _, asset := range f.assets {
+		assets = append(assets, asset)
+	}
+	return assets
+}
+
+// DeleteAsset removes an asset and its data. Tests use it to simulate a user deleting an asset in Eliona.
+func (f *Fake) DeleteAsset(_ context.Context, assetId int32) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.assets, assetId)
+	delete(f.data, assetId)
+	for id, rule := range f.rules {
+		if rule.AssetId == assetId {
+			delete(f.rules, id)
+		}
+	}
+	return nil
+}
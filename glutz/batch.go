@@ -0,0 +1,140 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package glutz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Result holds the outcome of a single call of a batch. It is filled when the batch is sent.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+// Batch collects calls to the Glutz server and sends them as JSON-RPC 2.0 batch requests.
+type Batch struct {
+	client *Client
+	calls  []batchCall
+}
+
+type batchCall struct {
+	request request
+	resolve func(result json.RawMessage, err error)
+}
+
+// NewBatch creates an empty batch for the client.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{client: c}
+}
+
+// GetDeviceStatus adds a call for the status of the device with the given device id.
+func (b *Batch) GetDeviceStatus(deviceId string) *Result[DeviceStatus] {
+	return enqueue(b, func(status []DeviceStatus) (DeviceStatus, error) {
+		return deviceStatusFromResult(deviceId, status)
+	}, "eAccess.getModel", "DeviceStatus", deviceParams{DeviceID: deviceId})
+}
+
+// GetLocation adds a call for building, room and name of the given access point.
+func (b *Batch) GetLocation(accessPointId string) *Result[Location] {
+	return enqueue(b, func(location []string) (Location, error) {
+		return locationFromResult(accessPointId, location)
+	}, "eAccess.getAccessPointProperty", locationProperty, accessPointId)
+}
+
+// Send sends all calls of the batch, split into requests of at most the configured batch size.
+// Errors are reported per call in the corresponding result.
+func (b *Batch) Send(ctx context.Context) {
+	for start := 0; start < len(b.calls); start += b.client.batchSize {
+		end := start + b.client.batchSize
+		if end > len(b.calls) {
+			end = len(b.calls)
+		}
+		b.client.sendBatch(ctx, b.calls[start:end])
+	}
+	b.calls = nil
+}
+
+func enqueue[T any, V any](b *Batch, convert func(T) (V, error), method string, params ...interface{}) *Result[V] {
+	result := &Result[V]{}
+	b.calls = append(b.calls, batchCall{
+		request: b.client.newRequest(method, params...),
+		resolve: func(raw json.RawMessage, err error) {
+			if err != nil {
+				result.Err = fmt.Errorf("calling %s: %w", method, err)
+				return
+			}
+			var value T
+			if err := json.Unmarshal(raw, &value); err != nil {
+				result.Err = fmt.Errorf("unmarshaling %s response: %w", method, err)
+				return
+			}
+			result.Value, result.Err = convert(value)
+		},
+	})
+	return result
+}
+
+func (c *Client) sendBatch(ctx context.Context, calls []batchCall) {
+	requests := make([]request, len(calls))
+	for i, call := range calls {
+		requests[i] = call.request
+	}
+	responses, err := c.postBatch(ctx, requests)
+	if err != nil {
+		for _, call := range calls {
+			call.resolve(nil, err)
+		}
+		return
+	}
+	responsesById := make(map[string]response[json.RawMessage], len(responses))
+	for _, resp := range responses {
+		responsesById[resp.Id] = resp
+	}
+	for _, call := range calls {
+		resp, ok := responsesById[call.request.ID]
+		switch {
+		case !ok:
+			call.resolve(nil, fmt.Errorf("glutz: no response for request id %s", call.request.ID))
+		case resp.Error != nil:
+			call.resolve(nil, resp.Error)
+		default:
+			call.resolve(resp.Result, nil)
+		}
+	}
+}
+
+func (c *Client) postBatch(ctx context.Context, requests []request) ([]response[json.RawMessage], error) {
+	payload, err := c.post(ctx, requests)
+	if err != nil {
+		return nil, err
+	}
+	var responses []response[json.RawMessage]
+	if err := json.Unmarshal(payload, &responses); err == nil {
+		return responses, nil
+	}
+	// If the batch as a whole is invalid, the server answers with a single error response.
+	var resp response[json.RawMessage]
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshaling batch response: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return nil, fmt.Errorf("glutz: unexpected batch response")
+}
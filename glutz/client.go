@@ -0,0 +1,238 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package glutz
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"glutz/apiserver"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	utilshttp "github.com/eliona-smart-building-assistant/go-utils/http"
+)
+
+// OpenableDurationProperty is the access point property on the Glutz server holding the openable duration in seconds.
+const OpenableDurationProperty = "/Properties/Eliona/Openable Duration [s]"
+
+const locationProperty = "location"
+
+// ErrUnauthorized is returned if the Glutz server rejects the configured credentials.
+var ErrUnauthorized = errors.New("glutz: unauthorized")
+
+// ErrUnexpectedStatus is returned if the Glutz server answers with an HTTP error status.
+var ErrUnexpectedStatus = errors.New("glutz: unexpected status code")
+
+// Error is the error object of a JSON-RPC response.
+type Error struct {
+	Code    int64           `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("glutz: json-rpc error %d: %s", e.Code, e.Message)
+}
+
+type request struct {
+	Jsonrpc string        `json:"jsonrpc"`
+	ID      string        `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type response[T any] struct {
+	Id      string `json:"id"`
+	Jsonrpc string `json:"jsonrpc"`
+	Result  T      `json:"result"`
+	Error   *Error `json:"error"`
+}
+
+type deviceParams struct {
+	DeviceID string `json:"deviceid"`
+}
+
+type durationParams struct {
+	Duration string `json:"Duration"`
+}
+
+// Location is the location of an access point as configured on the Glutz server.
+type Location struct {
+	Building    string
+	Room        string
+	AccessPoint string
+}
+
+// Client calls the eAccess JSON-RPC API of the Glutz server defined by one configuration.
+type Client struct {
+	config    apiserver.Configuration
+	timeout   time.Duration
+	batchSize int
+	nextId    uint64
+}
+
+// NewClient creates a client for the Glutz server of the given configuration. Each request is limited
+// by the given timeout, batches are split into batch requests of at most batchSize calls.
+func NewClient(config apiserver.Configuration, timeout time.Duration, batchSize int) *Client {
+	return &Client{
+		config:    config,
+		timeout:   timeout,
+		batchSize: batchSize,
+	}
+}
+
+// GetDevices returns all devices known by the Glutz server.
+func (c *Client) GetDevices(ctx context.Context) ([]DeviceResult, error) {
+	return call[[]DeviceResult](ctx, c, "eAccess.getModel", "Devices")
+}
+
+// GetDeviceStatus returns the status of the device with the given device id.
+func (c *Client) GetDeviceStatus(ctx context.Context, deviceId string) (*DeviceStatus, error) {
+	status, err := call[[]DeviceStatus](ctx, c, "eAccess.getModel", "DeviceStatus", deviceParams{DeviceID: deviceId})
+	if err != nil {
+		return nil, err
+	}
+	deviceStatus, err := deviceStatusFromResult(deviceId, status)
+	if err != nil {
+		return nil, err
+	}
+	return &deviceStatus, nil
+}
+
+// GetLocation returns building, room and name of the given access point.
+func (c *Client) GetLocation(ctx context.Context, accessPointId string) (*Location, error) {
+	location, err := call[[]string](ctx, c, "eAccess.getAccessPointProperty", locationProperty, accessPointId)
+	if err != nil {
+		return nil, err
+	}
+	apLocation, err := locationFromResult(accessPointId, location)
+	if err != nil {
+		return nil, err
+	}
+	return &apLocation, nil
+}
+
+// GetAccessPointProperty returns the value of a property of the given access point.
+func (c *Client) GetAccessPointProperty(ctx context.Context, property string, accessPointId string) (string, error) {
+	return call[string](ctx, c, "eAccess.getAccessPointProperty", property, accessPointId)
+}
+
+// SetAccessPointProperty defines a property for the given access point. An empty access point id
+// defines the property for all access points.
+func (c *Client) SetAccessPointProperty(ctx context.Context, property string, accessPointId string, value string) (bool, error) {
+	return call[bool](ctx, c, "eAccess.setAccessPointProperty", property, accessPointId, value)
+}
+
+// OpenAccessPoint opens the given access point. A duration of zero closes the access point again.
+func (c *Client) OpenAccessPoint(ctx context.Context, accessPointId string, duration time.Duration) (bool, error) {
+	return call[bool](ctx, c, "eAccess.openAccessPoint", accessPointId, durationParams{Duration: FormatDuration(duration)})
+}
+
+func deviceStatusFromResult(deviceId string, status []DeviceStatus) (DeviceStatus, error) {
+	if len(status) == 0 {
+		return DeviceStatus{}, fmt.Errorf("glutz: no status for device %s", deviceId)
+	}
+	return status[0], nil
+}
+
+func locationFromResult(accessPointId string, location []string) (Location, error) {
+	if len(location) < 3 {
+		return Location{}, fmt.Errorf("glutz: incomplete location %v for access point %s", location, accessPointId)
+	}
+	return Location{Building: location[0], Room: location[1], AccessPoint: location[2]}, nil
+}
+
+func (c *Client) newRequest(method string, params ...interface{}) request {
+	return request{
+		Jsonrpc: "2.0",
+		ID:      strconv.FormatUint(atomic.AddUint64(&c.nextId, 1), 10),
+		Method:  method,
+		Params:  params,
+	}
+}
+
+func (c *Client) post(ctx context.Context, body any) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	httpRequest, err := utilshttp.NewPostRequest(strings.TrimRight(c.config.Url, "/")+"/rpc", body)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	httpRequest = httpRequest.WithContext(ctx)
+	httpRequest.Header.Add("Referer", c.config.Url)
+	httpRequest.SetBasicAuth(c.config.Username, c.config.Password)
+	payload, statusCode, err := utilshttp.DoWithStatusCode(httpRequest, c.timeout, true)
+	if err != nil {
+		return nil, err
+	}
+	if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+		return nil, ErrUnauthorized
+	}
+	if statusCode >= 300 {
+		return nil, fmt.Errorf("%w %d", ErrUnexpectedStatus, statusCode)
+	}
+	return payload, nil
+}
+
+func call[T any](ctx context.Context, c *Client, method string, params ...interface{}) (T, error) {
+	var result T
+	req := c.newRequest(method, params...)
+	payload, err := c.post(ctx, req)
+	if err != nil {
+		return result, fmt.Errorf("calling %s: %w", method, err)
+	}
+	var resp response[T]
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return result, fmt.Errorf("unmarshaling %s response: %w", method, err)
+	}
+	if resp.Error != nil {
+		return result, fmt.Errorf("calling %s: %w", method, resp.Error)
+	}
+	if resp.Id != req.ID {
+		return result, fmt.Errorf("calling %s: response id %s does not match request id %s", method, resp.Id, req.ID)
+	}
+	return resp.Result, nil
+}
+
+// FormatDuration formats a duration as hh:mm:ss as expected by the eAccess API.
+func FormatDuration(duration time.Duration) string {
+	seconds := int(duration.Seconds())
+	return fmt.Sprintf("%02d:%02d:%02d", seconds/3600, (seconds%3600)/60, seconds%60)
+}
+
+// lastUpdateLayouts are the formats of the lastUpdate timestamp of a device status. Timestamps without
+// zone are local times of the Glutz server.
+var lastUpdateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+}
+
+// ParseLastUpdate parses the lastUpdate timestamp of a device status. Timestamps without zone are read in the
+// given time zone of the Glutz server.
+func ParseLastUpdate(value string, location *time.Location) (time.Time, error) {
+	for _, layout := range lastUpdateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("glutz: invalid last update %q", value)
+}
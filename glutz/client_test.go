@@ -0,0 +1,337 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package glutz_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"glutz/apiserver"
+	"glutz/glutz"
+	"glutz/glutz/mock"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func newMock(t *testing.T, fixtures mock.Fixtures) (*mock.Server, apiserver.Configuration) {
+	t.Helper()
+	server := mock.NewServer("user", "secret", fixtures)
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	return server, apiserver.Configuration{
+		ConfigId: 1,
+		Username: "user",
+		Password: "secret",
+		Url:      httpServer.URL,
+	}
+}
+
+func TestGetDevices(t *testing.T) {
+	_, config := newMock(t, mock.DemoFixtures(3))
+	devices, err := glutz.NewClient(config, 5*time.Second, 50).GetDevices(context.Background())
+	if err != nil {
+		t.Fatalf("GetDevices: %v", err)
+	}
+	if len(devices) != 3 {
+		t.Fatalf("got %d devices, want 3", len(devices))
+	}
+	if devices[0].Deviceid != "572.000.001" || devices[0].AccessPointId != "ap-1" {
+		t.Errorf("unexpected first device %+v", devices[0])
+	}
+}
+
+func TestGetDeviceStatusAndLocation(t *testing.T) {
+	_, config := newMock(t, mock.DemoFixtures(2))
+	client := glutz.NewClient(config, 5*time.Second, 50)
+	status, err := client.GetDeviceStatus(context.Background(), "572.000.002")
+	if err != nil {
+		t.Fatalf("GetDeviceStatus: %v", err)
+	}
+	if status.DeviceId != "572.000.002" || status.BatteryLevel != 98 || status.Openings != 20 {
+		t.Errorf("unexpected status %+v", status)
+	}
+	location, err := client.GetLocation(context.Background(), "ap-2")
+	if err != nil {
+		t.Fatalf("GetLocation: %v", err)
+	}
+	if *location != (glutz.Location{Building: "Building", Room: "Room 1", AccessPoint: "Door 2"}) {
+		t.Errorf("unexpected location %+v", location)
+	}
+	if _, err := client.GetDeviceStatus(context.Background(), "unknown"); err == nil {
+		t.Error("expected error for unknown device")
+	}
+}
+
+func TestAccessPointProperty(t *testing.T) {
+	_, config := newMock(t, mock.DemoFixtures(2))
+	client := glutz.NewClient(config, 5*time.Second, 50)
+	ctx := context.Background()
+	if ok, err := client.SetAccessPointProperty(ctx, glutz.OpenableDurationProperty, "", "0"); err != nil || !ok {
+		t.Fatalf("SetAccessPointProperty default: %v, %v", ok, err)
+	}
+	if ok, err := client.SetAccessPointProperty(ctx, glutz.OpenableDurationProperty, "ap-1", "15"); err != nil || !ok {
+		t.Fatalf("SetAccessPointProperty: %v, %v", ok, err)
+	}
+	for accessPointId, want := range map[string]string{"ap-1": "15", "ap-2": "0"} {
+		value, err := client.GetAccessPointProperty(ctx, glutz.OpenableDurationProperty, accessPointId)
+		if err != nil {
+			t.Fatalf("GetAccessPointProperty: %v", err)
+		}
+		if value != want {
+			t.Errorf("access point %s has value %q, want %q", accessPointId, value, want)
+		}
+	}
+}
+
+func TestOpenAccessPoint(t *testing.T) {
+	server, config := newMock(t, mock.DemoFixtures(1))
+	opened, err := glutz.NewClient(config, 5*time.Second, 50).OpenAccessPoint(context.Background(), "ap-1", 90*time.Second)
+	if err != nil || !opened {
+		t.Fatalf("OpenAccessPoint: %v, %v", opened, err)
+	}
+	opens := server.Opens()
+	if len(opens) != 1 || opens[0].AccessPointId != "ap-1" || opens[0].Duration != "00:01:30" {
+		t.Errorf("unexpected opens %+v", opens)
+	}
+}
+
+func TestUnauthorized(t *testing.T) {
+	_, config := newMock(t, mock.DemoFixtures(1))
+	config.Password = "wrong"
+	_, err := glutz.NewClient(config, 5*time.Second, 50).GetDevices(context.Background())
+	if !errors.Is(err, glutz.ErrUnauthorized) {
+		t.Errorf("got error %v, want %v", err, glutz.ErrUnauthorized)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	server, config := newMock(t, mock.DemoFixtures(1))
+	client := glutz.NewClient(config, 5*time.Second, 50)
+
+	server.SetHTTPStatus(http.StatusInternalServerError)
+	if _, err := client.GetDevices(context.Background()); err == nil {
+		t.Error("expected error for HTTP status 500")
+	}
+	server.ClearErrors()
+
+	server.InjectError("eAccess.getModel", "", 42, "broken")
+	_, err := client.GetDevices(context.Background())
+	var rpcErr *glutz.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != 42 {
+		t.Errorf("got error %v, want json-rpc error 42", err)
+	}
+}
+
+// rpcRequest is a JSON-RPC request received by a test server
+type rpcRequest struct {
+	Jsonrpc string            `json:"jsonrpc"`
+	ID      string            `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+// Starts a server answering each JSON-RPC request with the response built by respond
+func newRecordingServer(t *testing.T, respond func(r *http.Request, req rpcRequest) map[string]interface{}) apiserver.Configuration {
+	t.Helper()
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(respond(r, req))
+	}))
+	t.Cleanup(httpServer.Close)
+	return apiserver.Configuration{ConfigId: 1, Username: "user", Password: "secret", Url: httpServer.URL + "/"}
+}
+
+func TestRequest(t *testing.T) {
+	var requests []rpcRequest
+	var config apiserver.Configuration
+	config = newRecordingServer(t, func(r *http.Request, req rpcRequest) map[string]interface{} {
+		if r.URL.Path != "/rpc" {
+			t.Errorf("got path %s, want /rpc", r.URL.Path)
+		}
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "secret" {
+			t.Errorf("got basic auth %q, %q, %v, want user and secret", username, password, ok)
+		}
+		if referer := r.Header.Get("Referer"); referer != config.Url {
+			t.Errorf("got referer %q, want %q", referer, config.Url)
+		}
+		requests = append(requests, req)
+		return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": []interface{}{}}
+	})
+	client := glutz.NewClient(config, 5*time.Second, 50)
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetDevices(context.Background()); err != nil {
+			t.Fatalf("GetDevices: %v", err)
+		}
+	}
+
+	if len(requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(requests))
+	}
+	for i, req := range requests {
+		if want := strconv.Itoa(i + 1); req.ID != want {
+			t.Errorf("request %d has id %s, want %s", i, req.ID, want)
+		}
+		if req.Jsonrpc != "2.0" || req.Method != "eAccess.getModel" || len(req.Params) != 1 || string(req.Params[0]) != `"Devices"` {
+			t.Errorf("unexpected request %+v", req)
+		}
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	config := newRecordingServer(t, func(_ *http.Request, req rpcRequest) map[string]interface{} {
+		return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "error": map[string]interface{}{
+			"code": -32602, "message": "Invalid params", "data": map[string]string{"param": "deviceid"},
+		}}
+	})
+	_, err := glutz.NewClient(config, 5*time.Second, 50).GetDeviceStatus(context.Background(), "572.000.001")
+	var rpcErr *glutz.Error
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("got error %v, want json-rpc error", err)
+	}
+	if rpcErr.Code != -32602 || rpcErr.Message != "Invalid params" || string(rpcErr.Data) != `{"param":"deviceid"}` {
+		t.Errorf("got error %+v", rpcErr)
+	}
+	if want := "calling eAccess.getModel: glutz: json-rpc error -32602: Invalid params"; err.Error() != want {
+		t.Errorf("got error message %q, want %q", err.Error(), want)
+	}
+}
+
+func TestResponseIdMismatch(t *testing.T) {
+	config := newRecordingServer(t, func(_ *http.Request, req rpcRequest) map[string]interface{} {
+		return map[string]interface{}{"jsonrpc": "2.0", "id": req.ID + "0", "result": []interface{}{}}
+	})
+	if _, err := glutz.NewClient(config, 5*time.Second, 50).GetDevices(context.Background()); err == nil {
+		t.Error("expected error for response to another request")
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	server, config := newMock(t, mock.DemoFixtures(1))
+	server.SetLatency(2 * time.Second)
+	start := time.Now()
+	if _, err := glutz.NewClient(config, time.Second, 50).GetDevices(context.Background()); err == nil {
+		t.Error("expected timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 1800*time.Millisecond {
+		t.Errorf("request took %v despite timeout of 1s", elapsed)
+	}
+}
+
+func TestBatch(t *testing.T) {
+	fixtures := mock.DemoFixtures(7)
+	server, config := newMock(t, fixtures)
+	server.InjectError("eAccess.getModel", "572.000.003", 7, "device offline")
+	client := glutz.NewClient(config, 5*time.Second, 4)
+
+	batch := client.NewBatch()
+	statuses := make(map[string]*glutz.Result[glutz.DeviceStatus])
+	locations := make(map[string]*glutz.Result[glutz.Location])
+	for _, device := range fixtures.Devices {
+		statuses[device.DeviceId] = batch.GetDeviceStatus(device.DeviceId)
+		locations[device.DeviceId] = batch.GetLocation(device.AccessPointId)
+	}
+	batch.Send(context.Background())
+
+	// 14 calls in chunks of 4
+	if requests := server.Requests(); requests != 4 {
+		t.Errorf("got %d requests, want 4", requests)
+	}
+	for deviceId, status := range statuses {
+		if deviceId == "572.000.003" {
+			if status.Err == nil {
+				t.Errorf("expected error for device %s", deviceId)
+			}
+			continue
+		}
+		if status.Err != nil {
+			t.Errorf("device %s: %v", deviceId, status.Err)
+			continue
+		}
+		if status.Value.DeviceId != deviceId {
+			t.Errorf("got status of device %s for device %s", status.Value.DeviceId, deviceId)
+		}
+	}
+	for deviceId, location := range locations {
+		if location.Err != nil {
+			t.Errorf("location of device %s: %v", deviceId, location.Err)
+		}
+	}
+}
+
+func TestBatchTransportError(t *testing.T) {
+	server, config := newMock(t, mock.DemoFixtures(2))
+	server.SetHTTPStatus(http.StatusBadGateway)
+	batch := glutz.NewClient(config, 5*time.Second, 50).NewBatch()
+	first := batch.GetDeviceStatus("572.000.001")
+	second := batch.GetLocation("ap-2")
+	batch.Send(context.Background())
+	if first.Err == nil || second.Err == nil {
+		t.Errorf("expected errors for all calls, got %v and %v", first.Err, second.Err)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "00:00:00"},
+		{10 * time.Second, "00:00:10"},
+		{90 * time.Minute, "01:30:00"},
+		{25*time.Hour + 61*time.Second, "25:01:01"},
+	}
+	for _, tt := range tests {
+		if got := glutz.FormatDuration(tt.duration); got != tt.want {
+			t.Errorf("FormatDuration(%v) = %s, want %s", tt.duration, got, tt.want)
+		}
+	}
+}
+
+func TestParseLastUpdate(t *testing.T) {
+	zurich, err := time.LoadLocation("Europe/Zurich")
+	if err != nil {
+		t.Fatal(err)
+	}
+	utc := time.Date(2024, 3, 1, 12, 30, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		// Timestamps with zone don't depend on the time zone of the server
+		{"2024-03-01T12:30:00Z", utc},
+		{"2024-03-01T13:30:00+01:00", utc},
+		// Timestamps without zone are local times of the server, UTC+1 in Zurich in March
+		{"2024-03-01T13:30:00", utc},
+		{"2024-03-01 13:30:00", utc},
+	}
+	for _, tt := range tests {
+		got, err := glutz.ParseLastUpdate(tt.value, zurich)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("ParseLastUpdate(%s) = %v, %v, want %v", tt.value, got, err, tt.want)
+		}
+	}
+	if _, err := glutz.ParseLastUpdate("yesterday", zurich); err == nil {
+		t.Error("expected error for invalid last update")
+	}
+}
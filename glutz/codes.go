@@ -0,0 +1,56 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package glutz
+
+import "fmt"
+
+// Text is the English and German text of a code reported by Glutz devices.
+type Text struct {
+	En string
+	De string
+}
+
+// Operating modes reported by the devices: a door in office mode is permanently open, in normal mode it is
+// opened by authorized media and open commands only. The values are assumed and not confirmed by the eAccess
+// documentation, so they are not listed in OperatingModes.
+const (
+	OperatingModeNormal int64 = 0
+	OperatingModeOffice int64 = 1
+)
+
+// OperatingModes are the named operating modes reported in the device status. Only codes confirmed by the
+// eAccess documentation are listed, which is not available yet. Other modes are shown with their code.
+var OperatingModes = map[int64]Text{}
+
+// ErrorCodes are the named codes reported as last error in the device status. As for the operating modes,
+// only confirmed codes are listed, other codes are shown with their code.
+var ErrorCodes = map[int64]Text{}
+
+// OperatingModeText returns the text of an operating mode. Modes without name are returned with their code.
+func OperatingModeText(mode int64) Text {
+	if text, ok := OperatingModes[mode]; ok {
+		return text
+	}
+	return Text{En: fmt.Sprintf("Operating mode %d", mode), De: fmt.Sprintf("Betriebsmodus %d", mode)}
+}
+
+// ErrorText returns the text of an error code. Codes without name are returned with their code.
+func ErrorText(code int64) Text {
+	if text, ok := ErrorCodes[code]; ok {
+		return text
+	}
+	return Text{En: fmt.Sprintf("Error code %d", code), De: fmt.Sprintf("Fehlercode %d", code)}
+}
@@ -0,0 +1,79 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package glutz_test
+
+import (
+	"encoding/json"
+	"glutz/glutz"
+	"os"
+	"testing"
+)
+
+func TestCodeTexts(t *testing.T) {
+	if got := glutz.OperatingModeText(1); got.En != "Operating mode 1" || got.De != "Betriebsmodus 1" {
+		t.Errorf("got operating mode %+v", got)
+	}
+	if got := glutz.ErrorText(99); got.En != "Error code 99" || got.De != "Fehlercode 99" {
+		t.Errorf("got error %+v", got)
+	}
+}
+
+// The enum mappings of the asset type have to list the codes of the code tables with their English and
+// German texts
+func TestAssetTypeCodeMappings(t *testing.T) {
+	payload, err := os.ReadFile("../eliona/asset-type-glutz_device.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var assetType struct {
+		Attributes []struct {
+			Name string `json:"name"`
+			Map  []struct {
+				Value       int64  `json:"value"`
+				Text        string `json:"text"`
+				Translation struct {
+					De string `json:"de"`
+					En string `json:"en"`
+				} `json:"translation"`
+			} `json:"map"`
+		} `json:"attributes"`
+	}
+	if err := json.Unmarshal(payload, &assetType); err != nil {
+		t.Fatal(err)
+	}
+	codes := map[string]map[int64]glutz.Text{
+		"operatingMode": glutz.OperatingModes,
+		"lastError":     glutz.ErrorCodes,
+	}
+	for _, attribute := range assetType.Attributes {
+		table, ok := codes[attribute.Name]
+		if !ok {
+			continue
+		}
+		delete(codes, attribute.Name)
+		if len(attribute.Map) != len(table) {
+			t.Errorf("%s: got %d mappings, want %d", attribute.Name, len(attribute.Map), len(table))
+		}
+		for _, mapping := range attribute.Map {
+			if text, ok := table[mapping.Value]; !ok || text.En != mapping.Text || text.En != mapping.Translation.En || text.De != mapping.Translation.De {
+				t.Errorf("%s: mapping %d to %q does not match the code table", attribute.Name, mapping.Value, mapping.Text)
+			}
+		}
+	}
+	for name := range codes {
+		t.Errorf("attribute %s not found", name)
+	}
+}
@@ -0,0 +1,119 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package mock
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Fixtures defines the devices and access points served by the mock server.
+type Fixtures struct {
+	Devices      []Device               `json:"devices"`
+	AccessPoints map[string]AccessPoint `json:"accessPoints"`
+}
+
+// Device is a Glutz device as returned by eAccess.getModel.
+type Device struct {
+	Id            string `json:"id"`
+	DeviceId      string `json:"deviceid"`
+	AccessPointId string `json:"accessPointId"`
+	DeviceType    int64  `json:"deviceType"`
+	Label         string `json:"label"`
+
+	// Status holds the fields returned for the device by eAccess.getModel DeviceStatus. The device id is added
+	// by the server.
+	Status map[string]interface{} `json:"status,omitempty"`
+}
+
+// AccessPoint is an access point with its location and properties.
+type AccessPoint struct {
+	Building   string            `json:"building"`
+	Room       string            `json:"room"`
+	Name       string            `json:"name"`
+	Properties map[string]string `json:"properties"`
+}
+
+func (f Fixtures) clone() Fixtures {
+	clone := Fixtures{AccessPoints: make(map[string]AccessPoint, len(f.AccessPoints))}
+	for _, device := range f.Devices {
+		status := make(map[string]interface{}, len(device.Status))
+		for field, value := range device.Status {
+			status[field] = value
+		}
+		device.Status = status
+		clone.Devices = append(clone.Devices, device)
+	}
+	for id, accessPoint := range f.AccessPoints {
+		properties := make(map[string]string, len(accessPoint.Properties))
+		for property, value := range accessPoint.Properties {
+			properties[property] = value
+		}
+		accessPoint.Properties = properties
+		clone.AccessPoints[id] = accessPoint
+	}
+	return clone
+}
+
+// LoadFixtures reads fixtures from a JSON file.
+func LoadFixtures(path string) (Fixtures, error) {
+	var fixtures Fixtures
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fixtures, fmt.Errorf("reading fixtures: %w", err)
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return fixtures, fmt.Errorf("unmarshaling fixtures: %w", err)
+	}
+	return fixtures, nil
+}
+
+// DemoFixtures creates fixtures with the given number of devices, each on its own access point.
+func DemoFixtures(count int) Fixtures {
+	fixtures := Fixtures{AccessPoints: make(map[string]AccessPoint)}
+	lastUpdate := time.Now().UTC().Format(time.RFC3339)
+	for i := 1; i <= count; i++ {
+		accessPointId := fmt.Sprintf("ap-%d", i)
+		fixtures.Devices = append(fixtures.Devices, Device{
+			Id:            fmt.Sprintf("%d", i),
+			DeviceId:      fmt.Sprintf("572.%03d.%03d", i/1000, i%1000),
+			AccessPointId: accessPointId,
+			DeviceType:    102,
+			Label:         fmt.Sprintf("Lock %d", i),
+			Status: map[string]interface{}{
+				"batteryLevel":        100 - i%100,
+				"batteryAlarm":        100-i%100 < 20,
+				"batteryPowered":      true,
+				"openings":            i * 10,
+				"operatingMode":       0,
+				"firmware":            "3.4.1",
+				"communicationErrors": i % 3,
+				"irWakeups":           i * 2,
+				"rfWakeups":           i * 3,
+				"lastError":           0,
+				"lastUpdate":          lastUpdate,
+			},
+		})
+		fixtures.AccessPoints[accessPointId] = AccessPoint{
+			Building: "Building",
+			Room:     fmt.Sprintf("Room %d", (i-1)/10+1),
+			Name:     fmt.Sprintf("Door %d", i),
+		}
+	}
+	return fixtures
+}
@@ -0,0 +1,198 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package mock
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const locationProperty = "location"
+
+type deviceParams struct {
+	DeviceId string `json:"deviceid"`
+}
+
+type durationParams struct {
+	Duration string `json:"Duration"`
+}
+
+func (s *Server) handle(req request) response {
+	resp := response{Jsonrpc: "2.0", Id: req.Id}
+	if resp.Id == nil {
+		resp.Id = json.RawMessage("null")
+	}
+	result, rpcErr := s.call(req)
+	if rpcErr != nil {
+		resp.Error = rpcErr
+		return resp
+	}
+	resp.Result = result
+	return resp
+}
+
+func (s *Server) call(req request) (interface{}, *Error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch req.Method {
+	case "eAccess.getModel":
+		var model string
+		if err := param(req.Params, 0, &model); err != nil {
+			return nil, err
+		}
+		switch model {
+		case "Devices":
+			if err := s.injected(req.Method, ""); err != nil {
+				return nil, err
+			}
+			return s.devices(), nil
+		case "DeviceStatus":
+			var params deviceParams
+			if err := param(req.Params, 1, &params); err != nil {
+				return nil, err
+			}
+			if err := s.injected(req.Method, params.DeviceId); err != nil {
+				return nil, err
+			}
+			return s.deviceStatus(params.DeviceId), nil
+		}
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown model " + model}
+	case "eAccess.getAccessPointProperty":
+		var property, accessPointId string
+		if err := param(req.Params, 0, &property); err != nil {
+			return nil, err
+		}
+		if err := param(req.Params, 1, &accessPointId); err != nil {
+			return nil, err
+		}
+		if err := s.injected(req.Method, accessPointId); err != nil {
+			return nil, err
+		}
+		return s.accessPointProperty(property, accessPointId)
+	case "eAccess.setAccessPointProperty":
+		var property, accessPointId, value string
+		if err := param(req.Params, 0, &property); err != nil {
+			return nil, err
+		}
+		if err := param(req.Params, 1, &accessPointId); err != nil {
+			return nil, err
+		}
+		if err := param(req.Params, 2, &value); err != nil {
+			return nil, err
+		}
+		if err := s.injected(req.Method, accessPointId); err != nil {
+			return nil, err
+		}
+		return s.setAccessPointProperty(property, accessPointId, value)
+	case "eAccess.openAccessPoint":
+		var accessPointId string
+		var params durationParams
+		if err := param(req.Params, 0, &accessPointId); err != nil {
+			return nil, err
+		}
+		if err := param(req.Params, 1, &params); err != nil {
+			return nil, err
+		}
+		if err := s.injected(req.Method, accessPointId); err != nil {
+			return nil, err
+		}
+		if _, ok := s.fixtures.AccessPoints[accessPointId]; !ok {
+			return false, nil
+		}
+		open := Open{AccessPointId: accessPointId, Duration: params.Duration, Time: time.Now()}
+		s.opens = append(s.opens, open)
+		if s.onOpen != nil {
+			s.onOpen(open)
+		}
+		return true, nil
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: "Method not found"}
+}
+
+func (s *Server) injected(method string, target string) *Error {
+	for _, injected := range s.errors {
+		if injected.method == method && (injected.target == "" || injected.target == target) {
+			err := injected.err
+			return &err
+		}
+	}
+	return nil
+}
+
+func (s *Server) devices() []Device {
+	devices := make([]Device, len(s.fixtures.Devices))
+	for i, device := range s.fixtures.Devices {
+		devices[i] = device
+		devices[i].Status = nil
+	}
+	return devices
+}
+
+func (s *Server) deviceStatus(deviceId string) []map[string]interface{} {
+	for _, device := range s.fixtures.Devices {
+		if device.DeviceId != deviceId {
+			continue
+		}
+		status := map[string]interface{}{
+			"deviceid":   device.DeviceId,
+			"deviceType": device.DeviceType,
+		}
+		for field, value := range device.Status {
+			status[field] = value
+		}
+		return []map[string]interface{}{status}
+	}
+	return []map[string]interface{}{}
+}
+
+func (s *Server) accessPointProperty(property string, accessPointId string) (interface{}, *Error) {
+	accessPoint, ok := s.fixtures.AccessPoints[accessPointId]
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown access point " + accessPointId}
+	}
+	if property == locationProperty {
+		return []string{accessPoint.Building, accessPoint.Room, accessPoint.Name}, nil
+	}
+	if value, ok := accessPoint.Properties[property]; ok {
+		return value, nil
+	}
+	return s.defaults[property], nil
+}
+
+// An empty access point id defines the default value for all access points without own value.
+
+func (s *Server) setAccessPointProperty(property string, accessPointId string, value string) (interface{}, *Error) {
+	if accessPointId == "" {
+		s.defaults[property] = value
+		return true, nil
+	}
+	accessPoint, ok := s.fixtures.AccessPoints[accessPointId]
+	if !ok {
+		return nil, &Error{Code: CodeInvalidParams, Message: "unknown access point " + accessPointId}
+	}
+	accessPoint.Properties[property] = value
+	return true, nil
+}
+
+func param(params []json.RawMessage, index int, v interface{}) *Error {
+	if index >= len(params) {
+		return &Error{Code: CodeInvalidParams, Message: "Invalid params"}
+	}
+	if err := json.Unmarshal(params[index], v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "Invalid params"}
+	}
+	return nil
+}
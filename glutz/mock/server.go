@@ -0,0 +1,242 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+// Package mock provides a fake Glutz eAccess server implementing the JSON-RPC endpoint used by the app.
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JSON-RPC error codes used by the server.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+)
+
+// Error is an error returned in a JSON-RPC response.
+type Error struct {
+	Code    int64  `json:"code"`
+	Message string `json:"message"`
+}
+
+// Open is an eAccess.openAccessPoint call received by the server.
+type Open struct {
+	AccessPointId string
+	Duration      string
+	Time          time.Time
+}
+
+type request struct {
+	Jsonrpc string            `json:"jsonrpc"`
+	Id      json.RawMessage   `json:"id"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	Jsonrpc string          `json:"jsonrpc"`
+	Id      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type injectedError struct {
+	method string
+	target string
+	err    Error
+}
+
+// Server is a fake Glutz server. Use it as http.Handler, e.g. with httptest.NewServer.
+type Server struct {
+	username string
+	password string
+
+	mu         sync.Mutex
+	fixtures   Fixtures
+	defaults   map[string]string
+	latency    time.Duration
+	httpStatus int
+	errors     []injectedError
+	opens      []Open
+	onOpen     func(Open)
+	requests   int
+}
+
+// NewServer creates a server serving the fixtures to clients authenticating with the given credentials.
+// Changes made by the server don't affect the passed fixtures.
+func NewServer(username string, password string, fixtures Fixtures) *Server {
+	return &Server{
+		username: username,
+		password: password,
+		fixtures: fixtures.clone(),
+		defaults: make(map[string]string),
+	}
+}
+
+// SetLatency delays every HTTP request by the given duration.
+func (s *Server) SetLatency(latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = latency
+}
+
+// SetHTTPStatus lets every HTTP request fail with the given status code. Zero restores normal operation.
+func (s *Server) SetHTTPStatus(status int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.httpStatus = status
+}
+
+// InjectError lets calls of the method fail with a JSON-RPC error. The target restricts the error to calls
+// for a device id (eAccess.getModel) or an access point id (all other methods). An empty target matches all calls.
+func (s *Server) InjectError(method string, target string, code int64, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = append(s.errors, injectedError{method: method, target: target, err: Error{Code: code, Message: message}})
+}
+
+// ClearErrors removes all injected errors.
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors = nil
+	s.httpStatus = 0
+}
+
+// SetDeviceStatus replaces a field of the status of a device.
+func (s *Server) SetDeviceStatus(deviceId string, field string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, device := range s.fixtures.Devices {
+		if device.DeviceId == deviceId {
+			device.Status[field] = value
+		}
+	}
+}
+
+// OnOpen registers a function called for each received eAccess.openAccessPoint call.
+func (s *Server) OnOpen(f func(Open)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onOpen = f
+}
+
+// SetLocation changes the location of an access point.
+func (s *Server) SetLocation(accessPointId string, building string, room string, name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	accessPoint := s.fixtures.AccessPoints[accessPointId]
+	accessPoint.Building, accessPoint.Room, accessPoint.Name = building, room, name
+	if accessPoint.Properties == nil {
+		accessPoint.Properties = make(map[string]string)
+	}
+	s.fixtures.AccessPoints[accessPointId] = accessPoint
+}
+
+// RemoveDevice removes a device, as if it was removed from the Glutz controller.
+func (s *Server) RemoveDevice(deviceId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, device := range s.fixtures.Devices {
+		if device.DeviceId == deviceId {
+			s.fixtures.Devices = append(s.fixtures.Devices[:i], s.fixtures.Devices[i+1:]...)
+			return
+		}
+	}
+}
+
+// Opens returns all eAccess.openAccessPoint calls received so far.
+func (s *Server) Opens() []Open {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Open(nil), s.opens...)
+}
+
+// Requests returns the number of HTTP requests received so far.
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/rpc" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.Lock()
+	s.requests++
+	latency, httpStatus := s.latency, s.httpStatus
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+	if httpStatus != 0 {
+		w.WriteHeader(httpStatus)
+		return
+	}
+	username, password, ok := r.BasicAuth()
+	if !ok || username != s.username || password != s.password {
+		w.Header().Set("WWW-Authenticate", `Basic realm="eAccess"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var body json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, response{Jsonrpc: "2.0", Id: json.RawMessage("null"), Error: &Error{Code: CodeParseError, Message: "Parse error"}})
+		return
+	}
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var requests []request
+		if err := json.Unmarshal(body, &requests); err != nil || len(requests) == 0 {
+			writeJSON(w, response{Jsonrpc: "2.0", Id: json.RawMessage("null"), Error: &Error{Code: CodeInvalidRequest, Message: "Invalid Request"}})
+			return
+		}
+		responses := make([]response, len(requests))
+		for i, req := range requests {
+			responses[i] = s.handle(req)
+		}
+		writeJSON(w, responses)
+		return
+	}
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeJSON(w, response{Jsonrpc: "2.0", Id: json.RawMessage("null"), Error: &Error{Code: CodeInvalidRequest, Message: "Invalid Request"}})
+		return
+	}
+	writeJSON(w, s.handle(req))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
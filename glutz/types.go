@@ -1,10 +1,10 @@
 package glutz
 
-import "time"
-
 type DevicesDb map[string]DeviceDb
 
 type DeviceDb struct {
+	DeviceId      string `json:"deviceId"`
+	AccessPointId string `json:"accessPointId"`
 	BatteryLevel  int64  `json:"batteryLevel"`
 	Openings      int64  `json:"openings"`
 	Building      string `json:"building"`
@@ -13,12 +13,14 @@ type DeviceDb struct {
 	OperatingMode int64  `json:"operatingMode"`
 	Firmware      string `json:"firmware"`
 	Openable      int    `json:"openable"`
-}
 
-type DeviceGlutz struct {
-	Id      string         `json:"id"`
-	Jsonrpc string         `json:"jsonrpc"`
-	Result  []DeviceResult `json:"result"`
+	BatteryAlarm        bool   `json:"batteryAlarm"`
+	BatteryPowered      bool   `json:"batteryPowered"`
+	CommunicationErrors int64  `json:"communicationErrors"`
+	IrWakeups           int64  `json:"irWakeups"`
+	RfWakeups           int64  `json:"rfWakeups"`
+	LastError           int64  `json:"lastError"`
+	LastUpdate          string `json:"lastUpdate"`
 }
 
 type DeviceResult struct {
@@ -29,12 +31,6 @@ type DeviceResult struct {
 	Label         string `json:"label"`
 }
 
-type DeviceStatusGlutz struct {
-	Id      string         `json:"id"`
-	Jsonrpc string         `json:"jsonrpc"`
-	Result  []DeviceStatus `json:"result"`
-}
-
 type DeviceStatus struct {
 	BatteryAlarm        bool   `json:"batteryAlarm"`
 	BatteryLevel        int64  `json:"batteryLevel"`
@@ -50,33 +46,3 @@ type DeviceStatus struct {
 	OperatingMode       int64  `json:"operatingMode"`
 	RfWakeups           int64  `json:"rfWakeups"`
 }
-
-type DeviceAccessPointGlutz struct {
-	Id      string   `json:"id"`
-	Jsonrpc string   `json:"jsonrpc"`
-	Result  []string `json:"result"`
-}
-
-type Properties struct {
-	Id      string `json:"id"`
-	Jsonrpc string `json:"jsonrpc"`
-	Result  bool   `json:"result"`
-}
-
-type GlutzOpenableDuration struct {
-	Id      string `json:"id"`
-	Jsonrpc string `json:"jsonrpc"`
-	Result  string `json:"result"`
-}
-
-type AssetData []struct {
-	AssetID   int       `json:"assetId"`
-	Subtype   string    `json:"subtype"`
-	Timestamp time.Time `json:"timestamp"`
-	Data      struct {
-		BatteryLevel int `json:"batteryLevel"`
-		Openable     int `json:"openable"`
-		Openings     int `json:"openings"`
-	} `json:"data"`
-	AssetTypeName string `json:"assetTypeName"`
-}
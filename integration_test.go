@@ -34,7 +34,10 @@ func TestApp(t *testing.T) {
 func assetTypes(t *testing.T) {
 	t.Parallel()
 
-	assert.AssetTypeExists(t, "glutz_device", []string{"openable", "open", "openings"})
+	assert.AssetTypeExists(t, "glutz_device", []string{"batteryLevel", "openings", "batteryAlarm", "communicationErrors", "communicationErrorsIncrease", "irWakeups", "rfWakeups", "lastError", "lastErrorTextEn", "lastErrorTextDe", "online", "operatingMode", "operatingModeTextEn", "operatingModeTextDe", "firmware", "batteryPowered", "lastUpdate", "orphaned"})
+	assert.AssetTypeExists(t, "glutz_access_point", []string{"openable", "open"})
+	assert.AssetTypeExists(t, "glutz_building", []string{})
+	assert.AssetTypeExists(t, "glutz_room", []string{})
 }
 
 func widgetTypes(t *testing.T) {
@@ -16,7 +16,9 @@
 package main
 
 import (
+	"context"
 	"time"
+	_ "time/tzdata" // time zones of the configurations, also if the image has no zoneinfo
 
 	"github.com/eliona-smart-building-assistant/go-eliona/app"
 	"github.com/eliona-smart-building-assistant/go-utils/common"
@@ -43,9 +45,13 @@ func main() {
 	// Initialize the app
 	initialization()
 
+	// Recover door open commands interrupted by a restart
+	recoverCommands(context.Background(), confStore{})
+
 	// Starting the service to collect the data for this app.
 	common.WaitForWithOs(
 		common.Loop(checkConfigAndSetActiveState, time.Second),
+		common.Loop(closeOpenedDoors, time.Second),
 		listenForOutputChanges,
 		listenApiRequests,
 	)
@@ -0,0 +1,240 @@
+//  This file is part of the eliona project.
+//  Copyright © 2022 LEICOM iTEC AG. All Rights Reserved.
+//  ______ _ _
+// |  ____| (_)
+// | |__  | |_  ___  _ __   __ _
+// |  __| | | |/ _ \| '_ \ / _` |
+// | |____| | | (_) | | | | (_| |
+// |______|_|_|\___/|_| |_|\__,_|
+//
+//  THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+//  BUT NOT LIMITED  TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+//  NON INFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+//  DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+//  OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+
+package main
+
+import (
+	"context"
+	"glutz/apiserver"
+	"glutz/conf"
+	"sync"
+)
+
+// store persists configurations and device mappings for the sync and the output listener. It is
+// implemented by confStore using the app's database and replaced by an in-memory store in tests.
+type store interface {
+	GetConfig(ctx context.Context, configId int64) (*apiserver.Configuration, error)
+	SetConfigInitialisedState(ctx context.Context, configId int64, state bool) error
+	SetConfigCycleSummary(ctx context.Context, configId int64, summary apiserver.CycleSummary) error
+	SetConfigHealth(ctx context.Context, configId int64, health apiserver.ConnectionHealth) error
+	AreDeviceAssetsMigrated(ctx context.Context, configId int64) (bool, error)
+	SetDeviceAssetsMigrated(ctx context.Context, configId int64) error
+	GetDevices(ctx context.Context, configId int64) ([]apiserver.Device, error)
+	GetDevice(ctx context.Context, configId int64, projectId string, deviceId string) (*apiserver.Device, error)
+	InsertDevice(ctx context.Context, device apiserver.Device) error
+	UpdateDevice(ctx context.Context, device apiserver.Device) error
+	DeleteDevice(ctx context.Context, configId int64, projectId string, deviceId string) error
+	GetAccessPoints(ctx context.Context, configId int64) ([]apiserver.AccessPoint, error)
+	GetAccessPoint(ctx context.Context, configId int64, projectId string, accessPointId string) (*apiserver.AccessPoint, error)
+	GetAccessPointWithAssetId(ctx context.Context, assetId int32) (*apiserver.AccessPoint, error)
+	InsertAccessPoint(ctx context.Context, accessPoint apiserver.AccessPoint) error
+	UpdateAccessPoint(ctx context.Context, accessPoint apiserver.AccessPoint) error
+	DeleteAccessPoint(ctx context.Context, configId int64, projectId string, accessPointId string) error
+	GetAlarmRules(ctx context.Context, assetId int32) ([]conf.AlarmRule, error)
+	UpsertAlarmRule(ctx context.Context, alarmRule conf.AlarmRule) error
+	DeleteAlarmRules(ctx context.Context, assetId int32) error
+	GetCommands(ctx context.Context, states ...string) ([]conf.Command, error)
+	InsertCommand(ctx context.Context, command *conf.Command) error
+	UpdateCommand(ctx context.Context, command conf.Command) error
+	GetDoorState(ctx context.Context, configId int64, accessPointId string) (conf.DoorState, error)
+	GetDoorStates(ctx context.Context, states ...string) ([]conf.DoorState, error)
+	// UpdateDoorState passes the current door state of the access point to update and stores the state
+	// returned if update reports a change. Updates of the same access point don't overlap. Returns the
+	// resulting state and whether it changed.
+	UpdateDoorState(ctx context.Context, configId int64, accessPointId string, update func(current conf.DoorState) (conf.DoorState, bool)) (conf.DoorState, bool, error)
+}
+
+type confStore struct{}
+
+func (confStore) GetConfig(ctx context.Context, configId int64) (*apiserver.Configuration, error) {
+	return conf.GetConfig(ctx, configId)
+}
+
+func (confStore) SetConfigInitialisedState(_ context.Context, configId int64, state bool) error {
+	_, err := conf.SetConfigInitialisedState(configId, state)
+	return err
+}
+
+func (confStore) SetConfigCycleSummary(ctx context.Context, configId int64, summary apiserver.CycleSummary) error {
+	_, err := conf.SetConfigCycleSummary(ctx, configId, summary)
+	return err
+}
+
+func (confStore) SetConfigHealth(ctx context.Context, configId int64, health apiserver.ConnectionHealth) error {
+	_, err := conf.SetConfigHealth(ctx, configId, health)
+	return err
+}
+
+func (confStore) AreDeviceAssetsMigrated(ctx context.Context, configId int64) (bool, error) {
+	return conf.AreDeviceAssetsMigrated(ctx, configId)
+}
+
+func (confStore) SetDeviceAssetsMigrated(ctx context.Context, configId int64) error {
+	_, err := conf.SetDeviceAssetsMigrated(ctx, configId)
+	return err
+}
+
+func (confStore) GetDevices(ctx context.Context, configId int64) ([]apiserver.Device, error) {
+	return conf.GetDevices(ctx, configId)
+}
+
+func (confStore) GetDevice(ctx context.Context, configId int64, projectId string, deviceId string) (*apiserver.Device, error) {
+	return conf.GetDevice(ctx, configId, projectId, deviceId)
+}
+
+func (confStore) InsertDevice(ctx context.Context, device apiserver.Device) error {
+	return conf.InsertDevice(ctx, device)
+}
+
+func (confStore) UpdateDevice(ctx context.Context, device apiserver.Device) error {
+	_, err := conf.UpdateDevice(ctx, device)
+	return err
+}
+
+func (confStore) DeleteDevice(ctx context.Context, configId int64, projectId string, deviceId string) error {
+	_, err := conf.DeleteDevice(ctx, configId, projectId, deviceId)
+	return err
+}
+
+func (confStore) GetAccessPoints(ctx context.Context, configId int64) ([]apiserver.AccessPoint, error) {
+	return conf.GetAccessPoints(ctx, configId)
+}
+
+func (confStore) GetAccessPoint(ctx context.Context, configId int64, projectId string, accessPointId string) (*apiserver.AccessPoint, error) {
+	return conf.GetAccessPoint(ctx, configId, projectId, accessPointId)
+}
+
+func (confStore) GetAccessPointWithAssetId(ctx context.Context, assetId int32) (*apiserver.AccessPoint, error) {
+	return conf.GetAccessPointWithAssetId(ctx, assetId)
+}
+
+func (confStore) InsertAccessPoint(ctx context.Context, accessPoint apiserver.AccessPoint) error {
+	return conf.InsertAccessPoint(ctx, accessPoint)
+}
+
+func (confStore) UpdateAccessPoint(ctx context.Context, accessPoint apiserver.AccessPoint) error {
+	_, err := conf.UpdateAccessPoint(ctx, accessPoint)
+	return err
+}
+
+// DeleteAccessPoint also removes the commands and the door state of the access point. The cached door state
+// is dropped, so that it is read again from the database.
+func (confStore) DeleteAccessPoint(ctx context.Context, configId int64, projectId string, accessPointId string) error {
+	_, err := conf.DeleteAccessPoint(ctx, configId, projectId, accessPointId)
+	doorStateCache.Lock()
+	delete(doorStateCache.entries, accessPointKey{configId: configId, accessPointId: accessPointId})
+	doorStateCache.Unlock()
+	return err
+}
+
+func (confStore) GetAlarmRules(ctx context.Context, assetId int32) ([]conf.AlarmRule, error) {
+	return conf.GetAlarmRules(ctx, assetId)
+}
+
+func (confStore) UpsertAlarmRule(ctx context.Context, alarmRule conf.AlarmRule) error {
+	return conf.UpsertAlarmRule(ctx, alarmRule)
+}
+
+func (confStore) DeleteAlarmRules(ctx context.Context, assetId int32) error {
+	_, err := conf.DeleteAlarmRules(ctx, assetId)
+	return err
+}
+
+func (confStore) GetCommands(ctx context.Context, states ...string) ([]conf.Command, error) {
+	return conf.GetCommands(ctx, states...)
+}
+
+func (confStore) InsertCommand(ctx context.Context, command *conf.Command) error {
+	commandId, err := conf.InsertCommand(ctx, *command)
+	command.CommandId = commandId
+	return err
+}
+
+func (confStore) UpdateCommand(ctx context.Context, command conf.Command) error {
+	_, err := conf.UpdateCommand(ctx, command)
+	return err
+}
+
+// Door states are read for every output, so confStore keeps them in memory. Each state is read from the
+// database once, changes are written to both. The cache lock only guards the entries, reads and writes of
+// a door state hold the lock of its entry, so the database is never accessed with the cache locked.
+var doorStateCache = struct {
+	sync.Mutex
+	entries map[accessPointKey]*doorStateEntry
+}{entries: make(map[accessPointKey]*doorStateEntry)}
+
+type doorStateEntry struct {
+	sync.Mutex
+	loaded bool
+	state  conf.DoorState
+}
+
+// Returns the locked cache entry of the door state of the access point, read from the database if not yet
+// loaded. The caller must unlock the entry.
+func lockDoorStateEntry(ctx context.Context, configId int64, accessPointId string) (*doorStateEntry, error) {
+	key := accessPointKey{configId: configId, accessPointId: accessPointId}
+	doorStateCache.Lock()
+	entry, ok := doorStateCache.entries[key]
+	if !ok {
+		entry = &doorStateEntry{}
+		doorStateCache.entries[key] = entry
+	}
+	doorStateCache.Unlock()
+
+	entry.Lock()
+	if entry.loaded {
+		return entry, nil
+	}
+	doorState, err := conf.GetDoorState(ctx, configId, accessPointId)
+	if err != nil {
+		entry.Unlock()
+		return nil, err
+	}
+	if doorState == nil {
+		doorState = &conf.DoorState{ConfigId: configId, AccessPointId: accessPointId, State: conf.DoorStateClosed}
+	}
+	entry.state = *doorState
+	entry.loaded = true
+	return entry, nil
+}
+
+// GetDoorState returns the state of the door of the access point. Doors without stored state are closed.
+func (confStore) GetDoorState(ctx context.Context, configId int64, accessPointId string) (conf.DoorState, error) {
+	entry, err := lockDoorStateEntry(ctx, configId, accessPointId)
+	if err != nil {
+		return conf.DoorState{}, err
+	}
+	defer entry.Unlock()
+	return entry.state, nil
+}
+
+func (confStore) GetDoorStates(ctx context.Context, states ...string) ([]conf.DoorState, error) {
+	return conf.GetDoorStates(ctx, states...)
+}
+
+// UpdateDoorState keeps the state in memory also if it can't be written to the database.
+func (confStore) UpdateDoorState(ctx context.Context, configId int64, accessPointId string, update func(current conf.DoorState) (conf.DoorState, bool)) (conf.DoorState, bool, error) {
+	entry, err := lockDoorStateEntry(ctx, configId, accessPointId)
+	if err != nil {
+		return conf.DoorState{}, false, err
+	}
+	defer entry.Unlock()
+	doorState, changed := update(entry.state)
+	if !changed {
+		return entry.state, false, nil
+	}
+	entry.state = doorState
+	return doorState, true, conf.UpsertDoorState(ctx, doorState)
+}